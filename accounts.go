@@ -0,0 +1,298 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Accounts provides an api into aiven accounts and their teams
+type Accounts struct {
+	client *Client
+}
+
+// newAccounts accepts a valid aiven client and returns access to the Accounts api
+func newAccounts(client *Client) *Accounts {
+	return &Accounts{
+		client: client,
+	}
+}
+
+// Account represents an aiven account; projects belonging to an account are
+// accessed through its teams
+type Account struct {
+	AccountID   string    `json:"account_id"`
+	AccountName string    `json:"account_name"`
+	CreateTime  time.Time `json:"create_time"`
+	OwnerTeamID string    `json:"account_owner_team_id"`
+	TenantID    string    `json:"tenant_id,omitempty"`
+	UpdateTime  time.Time `json:"update_time"`
+}
+
+// Team represents a group of account users sharing access to projects
+type Team struct {
+	AccountID  string    `json:"account_id"`
+	CreateTime time.Time `json:"create_time"`
+	TeamID     string    `json:"team_id"`
+	TeamName   string    `json:"team_name"`
+	UpdateTime time.Time `json:"update_time"`
+}
+
+// TeamMember represents a user within a team
+type TeamMember struct {
+	CreateTime time.Time `json:"create_time"`
+	RealName   string    `json:"real_name"`
+	TeamID     string    `json:"team_id"`
+	TeamName   string    `json:"team_name"`
+	UserEmail  string    `json:"user_email"`
+	UserID     string    `json:"user_id"`
+}
+
+// TeamInvite represents a pending invitation to join a team
+type TeamInvite struct {
+	CreateTime         time.Time `json:"create_time"`
+	InvitedByUserEmail string    `json:"invited_by_user_email"`
+	TeamID             string    `json:"team_id"`
+	TeamName           string    `json:"team_name"`
+	UserEmail          string    `json:"user_email"`
+}
+
+// TeamProject describes the access a team has to a project
+type TeamProject struct {
+	ProjectName string     `json:"project_name"`
+	TeamType    MemberType `json:"team_type"`
+}
+
+// List returns the accounts visible to the authenticated user
+func (a *Accounts) List(ctx context.Context) ([]Account, error) {
+	out := struct {
+		apiErrors
+		Accounts []Account `json:"accounts"`
+	}{}
+	if err := a.client.Get(ctx, "https://console.aiven.io/v1beta/account", &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list accounts")
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list accounts")
+	}
+
+	return out.Accounts, nil
+}
+
+type TeamListIn struct {
+	AccountID string
+}
+
+// ListTeams returns the teams of the account
+func (a *Accounts) ListTeams(ctx context.Context, in TeamListIn) ([]Team, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/account/%v/teams", in.AccountID)
+	out := struct {
+		apiErrors
+		Teams []Team `json:"teams"`
+	}{}
+	if err := a.client.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list teams for account, %v", in.AccountID)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list teams for account, %v", in.AccountID)
+	}
+
+	return out.Teams, nil
+}
+
+type TeamCreateIn struct {
+	AccountID string `json:"-"`
+	TeamName  string `json:"team_name"`
+}
+
+// CreateTeam creates a new team within the account
+func (a *Accounts) CreateTeam(ctx context.Context, in TeamCreateIn) (Team, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/account/%v/teams", in.AccountID)
+	out := struct {
+		apiErrors
+		Team Team `json:"team"`
+	}{}
+	if err := a.client.Post(ctx, u, in, &out); err != nil {
+		return Team{}, errors.Wrapf(err, "unable to create team, %v, for account, %v", in.TeamName, in.AccountID)
+	}
+	if err := out.err(); err != nil {
+		return Team{}, errors.Wrapf(err, "unable to create team, %v, for account, %v", in.TeamName, in.AccountID)
+	}
+
+	return out.Team, nil
+}
+
+type TeamDeleteIn struct {
+	AccountID string
+	TeamID    string
+}
+
+// DeleteTeam removes the team. Deleting a team that does not exist is not an error.
+func (a *Accounts) DeleteTeam(ctx context.Context, in TeamDeleteIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/account/%v/team/%v", in.AccountID, in.TeamID)
+	out := apiErrors{}
+	if err := a.client.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to delete team, %v, for account, %v", in.TeamID, in.AccountID)
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to delete team, %v, for account, %v", in.TeamID, in.AccountID)
+	}
+
+	return nil
+}
+
+type TeamMembersIn struct {
+	AccountID string
+	TeamID    string
+}
+
+// ListTeamMembers returns the members of the team
+func (a *Accounts) ListTeamMembers(ctx context.Context, in TeamMembersIn) ([]TeamMember, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/account/%v/team/%v/members", in.AccountID, in.TeamID)
+	out := struct {
+		apiErrors
+		Members []TeamMember `json:"members"`
+	}{}
+	if err := a.client.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list members of team, %v", in.TeamID)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list members of team, %v", in.TeamID)
+	}
+
+	return out.Members, nil
+}
+
+// ListTeamInvites returns the pending invitations to join the team
+func (a *Accounts) ListTeamInvites(ctx context.Context, in TeamMembersIn) ([]TeamInvite, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/account/%v/team/%v/invites", in.AccountID, in.TeamID)
+	out := struct {
+		apiErrors
+		Invites []TeamInvite `json:"account_invites"`
+	}{}
+	if err := a.client.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list invites of team, %v", in.TeamID)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list invites of team, %v", in.TeamID)
+	}
+
+	return out.Invites, nil
+}
+
+type TeamAddMemberIn struct {
+	AccountID string `json:"-"`
+	TeamID    string `json:"-"`
+	Email     string `json:"email"`
+}
+
+// AddTeamMember invites the user to join the team; the user becomes a member once
+// the invitation is accepted
+func (a *Accounts) AddTeamMember(ctx context.Context, in TeamAddMemberIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/account/%v/team/%v/members", in.AccountID, in.TeamID)
+	out := apiErrors{}
+	if err := a.client.Post(ctx, u, in, &out); err != nil {
+		return errors.Wrapf(err, "unable to add user, %v, to team, %v", in.Email, in.TeamID)
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to add user, %v, to team, %v", in.Email, in.TeamID)
+	}
+
+	return nil
+}
+
+type TeamRemoveMemberIn struct {
+	AccountID string
+	TeamID    string
+	UserID    string
+}
+
+// RemoveTeamMember removes the user from the team. Removing a user that is not a
+// member is not an error.
+func (a *Accounts) RemoveTeamMember(ctx context.Context, in TeamRemoveMemberIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/account/%v/team/%v/member/%v", in.AccountID, in.TeamID, in.UserID)
+	out := apiErrors{}
+	if err := a.client.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to remove user, %v, from team, %v", in.UserID, in.TeamID)
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to remove user, %v, from team, %v", in.UserID, in.TeamID)
+	}
+
+	return nil
+}
+
+type TeamProjectsIn struct {
+	AccountID string
+	TeamID    string
+}
+
+// ListTeamProjects returns the projects the team has access to
+func (a *Accounts) ListTeamProjects(ctx context.Context, in TeamProjectsIn) ([]TeamProject, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/account/%v/team/%v/projects", in.AccountID, in.TeamID)
+	out := struct {
+		apiErrors
+		Projects []TeamProject `json:"projects"`
+	}{}
+	if err := a.client.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list projects of team, %v", in.TeamID)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list projects of team, %v", in.TeamID)
+	}
+
+	return out.Projects, nil
+}
+
+type TeamAttachProjectIn struct {
+	AccountID string     `json:"-"`
+	TeamID    string     `json:"-"`
+	Project   string     `json:"-"`
+	TeamType  MemberType `json:"team_type"`
+}
+
+// AttachProject grants the team access to the project with the role given by TeamType
+func (a *Accounts) AttachProject(ctx context.Context, in TeamAttachProjectIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/account/%v/team/%v/project/%v", in.AccountID, in.TeamID, in.Project)
+	out := apiErrors{}
+	if err := a.client.Post(ctx, u, in, &out); err != nil {
+		return errors.Wrapf(err, "unable to attach team, %v, to project, %v", in.TeamID, in.Project)
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to attach team, %v, to project, %v", in.TeamID, in.Project)
+	}
+
+	return nil
+}
+
+type TeamDetachProjectIn struct {
+	AccountID string
+	TeamID    string
+	Project   string
+}
+
+// DetachProject revokes the access of the team to the project. Detaching a project
+// the team is not attached to is not an error.
+func (a *Accounts) DetachProject(ctx context.Context, in TeamDetachProjectIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/account/%v/team/%v/project/%v", in.AccountID, in.TeamID, in.Project)
+	out := apiErrors{}
+	if err := a.client.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to detach team, %v, from project, %v", in.TeamID, in.Project)
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to detach team, %v, from project, %v", in.TeamID, in.Project)
+	}
+
+	return nil
+}
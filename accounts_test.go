@@ -0,0 +1,38 @@
+package aiven_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccounts(t *testing.T) {
+	accountID := os.Getenv("AIVEN_ACCOUNT_ID")
+
+	if accountID == "" {
+		t.Skip("AIVEN_ACCOUNT_ID not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	api := client.Accounts()
+	accounts, err := api.List(context.Background())
+	assert.Nil(t, err)
+	assert.NotEmpty(t, accounts)
+
+	teams, err := api.ListTeams(context.Background(), aiven.TeamListIn{
+		AccountID: accountID,
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, teams)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(teams)
+}
@@ -0,0 +1,152 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	InvoiceStateAccrual       = "accrual"
+	InvoiceStateConsolidated  = "consolidated"
+	InvoiceStateDue           = "due"
+	InvoiceStateEstimate      = "estimate"
+	InvoiceStateMailed        = "mailed"
+	InvoiceStatePaid          = "paid"
+	InvoiceStateUncollectible = "uncollectible"
+	InvoiceStateWaived        = "waived"
+)
+
+// Billing provides an api into the invoices and credits of aiven projects
+type Billing struct {
+	client *Client
+}
+
+// newBilling accepts a valid aiven client and returns access to the Billing api
+func newBilling(client *Client) *Billing {
+	return &Billing{
+		client: client,
+	}
+}
+
+// Invoice summarizes the charges of a project for a billing period
+type Invoice struct {
+	Currency       string     `json:"currency"`
+	DownloadCookie string     `json:"download_cookie,omitempty"`
+	GeneratedAt    *time.Time `json:"generated_at,omitempty"`
+	InvoiceNumber  string     `json:"invoice_number"`
+	PeriodBegin    time.Time  `json:"period_begin"`
+	PeriodEnd      time.Time  `json:"period_end"`
+	State          string     `json:"state"`
+	TotalIncVAT    string     `json:"total_inc_vat"`
+	TotalVATZero   string     `json:"total_vat_zero"`
+}
+
+// InvoiceLine is a single charge within an invoice, typically the usage of one service
+type InvoiceLine struct {
+	CloudName      string     `json:"cloud_name,omitempty"`
+	Description    string     `json:"description"`
+	LineTotalLocal string     `json:"line_total_local,omitempty"`
+	LineTotalUSD   string     `json:"line_total_usd"`
+	LineType       string     `json:"line_type"`
+	LocalCurrency  string     `json:"local_currency,omitempty"`
+	ProjectName    string     `json:"project_name,omitempty"`
+	ServiceName    string     `json:"service_name,omitempty"`
+	ServicePlan    string     `json:"service_plan,omitempty"`
+	ServiceType    string     `json:"service_type,omitempty"`
+	TimestampBegin *time.Time `json:"timestamp_begin,omitempty"`
+	TimestampEnd   *time.Time `json:"timestamp_end,omitempty"`
+}
+
+// Credit is an amount applied against the charges of a project
+type Credit struct {
+	Code           string     `json:"code,omitempty"`
+	ExpireTime     *time.Time `json:"expire_time,omitempty"`
+	RemainingValue string     `json:"remaining_value,omitempty"`
+	Type           string     `json:"type,omitempty"`
+	Value          string     `json:"value"`
+}
+
+type BillingInvoicesIn struct {
+	Project string
+}
+
+// Invoices returns the invoices of the project, including the estimate for the current period
+func (b *Billing) Invoices(ctx context.Context, in BillingInvoicesIn) ([]Invoice, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/invoice", in.Project)
+	out := struct {
+		apiErrors
+		Invoices []Invoice `json:"invoices"`
+	}{}
+	if err := b.client.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list invoices for project, %v", in.Project)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list invoices for project, %v", in.Project)
+	}
+
+	return out.Invoices, nil
+}
+
+type BillingInvoiceLinesIn struct {
+	// BillingGroupID is the billing group of the project; see Project.BillingGroupID
+	BillingGroupID string
+	InvoiceNumber  string
+}
+
+// InvoiceLines returns the individual charges of an invoice
+func (b *Billing) InvoiceLines(ctx context.Context, in BillingInvoiceLinesIn) ([]InvoiceLine, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/billing-group/%v/invoice/%v/lines", in.BillingGroupID, in.InvoiceNumber)
+	out := struct {
+		apiErrors
+		Lines []InvoiceLine `json:"lines"`
+	}{}
+	if err := b.client.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list lines of invoice, %v", in.InvoiceNumber)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list lines of invoice, %v", in.InvoiceNumber)
+	}
+
+	return out.Lines, nil
+}
+
+type BillingDownloadInvoiceIn struct {
+	Project        string
+	InvoiceNumber  string
+	DownloadCookie string
+}
+
+// DownloadInvoice writes the pdf of the invoice to w
+func (b *Billing) DownloadInvoice(ctx context.Context, in BillingDownloadInvoiceIn, w io.Writer) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/invoice/%v/%v", in.Project, in.InvoiceNumber, in.DownloadCookie)
+	if err := b.client.Download(ctx, u, w); err != nil {
+		return errors.Wrapf(err, "unable to download invoice, %v", in.InvoiceNumber)
+	}
+
+	return nil
+}
+
+type BillingCreditsIn struct {
+	Project string
+}
+
+// Credits returns the credits available to the project
+func (b *Billing) Credits(ctx context.Context, in BillingCreditsIn) ([]Credit, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/credits", in.Project)
+	out := struct {
+		apiErrors
+		Credits []Credit `json:"credits"`
+	}{}
+	if err := b.client.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list credits for project, %v", in.Project)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list credits for project, %v", in.Project)
+	}
+
+	return out.Credits, nil
+}
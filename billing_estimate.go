@@ -0,0 +1,124 @@
+package aiven
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ServiceCost is the estimated cost of a service over a billing period. Approximate is
+// true when the hours the service ran could not be determined, e.g. a service that is
+// powered off now may still have run for part of the period, so Hours and CostUSD
+// understate the cost.
+type ServiceCost struct {
+	Approximate bool    `json:"approximate"`
+	Cloud       string  `json:"cloud"`
+	CostUSD     float64 `json:"cost_usd"`
+	HourlyUSD   float64 `json:"hourly_usd"`
+	Hours       float64 `json:"hours"`
+	Plan        string  `json:"plan"`
+	Priced      bool    `json:"priced"`
+	Project     string  `json:"project"`
+	Service     string  `json:"service"`
+	ServiceType string  `json:"service_type"`
+}
+
+// MonthPeriod returns the start and end of the calendar month, in UTC, containing t
+func MonthPeriod(t time.Time) (begin, end time.Time) {
+	t = t.UTC()
+	begin = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return begin, begin.AddDate(0, 1, 0)
+}
+
+// EstimateServiceCost estimates the cost of the service between begin and end. Aiven
+// bills started hours up to HoursPerMonth per month. The power history of a service is
+// not available, so the cost of a service that is powered off now is not estimated and
+// is marked Approximate instead.
+func EstimateServiceCost(service Service, price PlanPrice, begin, end time.Time) ServiceCost {
+	cost := ServiceCost{
+		Cloud:       service.CloudName,
+		HourlyUSD:   price.HourlyUSD,
+		Plan:        service.Plan,
+		Service:     service.ServiceName,
+		ServiceType: service.ServiceType,
+	}
+
+	if service.CreateTime.After(begin) {
+		begin = service.CreateTime
+	}
+	if !end.After(begin) {
+		return cost
+	}
+	if service.State == ServiceStatePowerOff {
+		cost.Approximate = true
+		return cost
+	}
+
+	cost.Hours = math.Min(math.Ceil(end.Sub(begin).Hours()), HoursPerMonth)
+	cost.CostUSD = cost.Hours * price.HourlyUSD
+
+	return cost
+}
+
+type BillingEstimateIn struct {
+	Project string
+	// Month may be any time within the month to estimate; defaults to now
+	Month time.Time
+}
+
+// EstimateCosts estimates the cost of each service in the project for the month, up to
+// now, from plan pricing and service uptime. Only services that still exist are
+// included; use InvoiceLines for the exact charges of closed months. Priced is false
+// for services whose plan price could not be found.
+func (b *Billing) EstimateCosts(ctx context.Context, in BillingEstimateIn) ([]ServiceCost, error) {
+	now := time.Now()
+	month := in.Month
+	if month.IsZero() {
+		month = now
+	}
+
+	begin, end := MonthPeriod(month)
+	if now.Before(end) {
+		end = now
+	}
+
+	services, err := b.client.Services().List(ctx, ServiceListIn{
+		Project: in.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to estimate costs for project, %v", in.Project)
+	}
+
+	serviceTypes, err := b.client.ServiceTypes(ctx, ServiceTypesIn{
+		Project: in.Project,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to estimate costs for project, %v", in.Project)
+	}
+
+	var costs []ServiceCost
+	for _, service := range services {
+		var price PlanPrice
+		var priced bool
+		for _, plan := range serviceTypes[service.ServiceType].ServicePlans {
+			if plan.ServicePlan == service.Plan {
+				price, priced = plan.Price(service.CloudName)
+				break
+			}
+		}
+
+		cost := EstimateServiceCost(service, price, begin, end)
+		cost.Priced = priced
+		cost.Project = in.Project
+		costs = append(costs, cost)
+	}
+
+	sort.Slice(costs, func(i, j int) bool {
+		return costs[i].Service < costs[j].Service
+	})
+
+	return costs, nil
+}
@@ -0,0 +1,92 @@
+package aiven_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBilling(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	invoices, err := client.Billing().Invoices(context.Background(), aiven.BillingInvoicesIn{
+		Project: project,
+	})
+	assert.Nil(t, err)
+
+	costs, err := client.Billing().EstimateCosts(context.Background(), aiven.BillingEstimateIn{
+		Project: project,
+	})
+	assert.Nil(t, err)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(invoices)
+	encoder.Encode(costs)
+}
+
+func TestEstimateServiceCost(t *testing.T) {
+	begin, end := aiven.MonthPeriod(time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), begin)
+	assert.Equal(t, time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC), end)
+
+	price := aiven.PlanPrice{HourlyUSD: 0.5}
+
+	testCases := map[string]struct {
+		Service     aiven.Service
+		Hours       float64
+		Approximate bool
+	}{
+		"full month capped": {
+			Service: aiven.Service{
+				CreateTime: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+				State:      aiven.ServiceStateRunning,
+			},
+			Hours: aiven.HoursPerMonth,
+		},
+		"created mid month": {
+			Service: aiven.Service{
+				CreateTime: time.Date(2026, time.October, 31, 13, 30, 0, 0, time.UTC),
+				State:      aiven.ServiceStateRunning,
+			},
+			Hours: 11,
+		},
+		"created after period": {
+			Service: aiven.Service{
+				CreateTime: time.Date(2026, time.November, 2, 0, 0, 0, 0, time.UTC),
+				State:      aiven.ServiceStateRunning,
+			},
+			Hours: 0,
+		},
+		"powered off": {
+			Service: aiven.Service{
+				CreateTime: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+				State:      aiven.ServiceStatePowerOff,
+			},
+			Hours:       0,
+			Approximate: true,
+		},
+	}
+
+	for label, tc := range testCases {
+		t.Run(label, func(t *testing.T) {
+			cost := aiven.EstimateServiceCost(tc.Service, price, begin, end)
+			assert.Equal(t, tc.Hours, cost.Hours)
+			assert.Equal(t, tc.Hours*0.5, cost.CostUSD)
+			assert.Equal(t, tc.Approximate, cost.Approximate)
+		})
+	}
+}
@@ -21,10 +21,107 @@ type Client struct {
 	client *http.Client
 }
 
+// Token returns the token the client authenticates with, e.g. to cache a session
+func (c *Client) Token() string {
+	return c.token
+}
+
 func (c *Client) Kafka() *Kafka {
 	return newKafka(c)
 }
 
+func (c *Client) Projects() *Projects {
+	return newProjects(c)
+}
+
+func (c *Client) Services() *Services {
+	return newServices(c)
+}
+
+func (c *Client) Postgres() *Postgres {
+	return newPostgres(c)
+}
+
+func (c *Client) MySQL() *MySQL {
+	return newMySQL(c)
+}
+
+func (c *Client) Redis() *Redis {
+	return newRedis(c)
+}
+
+func (c *Client) OpenSearch() *OpenSearch {
+	return newOpenSearch(c)
+}
+
+func (c *Client) Integrations() *Integrations {
+	return newIntegrations(c)
+}
+
+func (c *Client) VPC() *VPC {
+	return newVPC(c)
+}
+
+func (c *Client) StaticIPs() *StaticIPs {
+	return newStaticIPs(c)
+}
+
+func (c *Client) Members() *Members {
+	return newMembers(c)
+}
+
+func (c *Client) Accounts() *Accounts {
+	return newAccounts(c)
+}
+
+func (c *Client) Tokens() *Tokens {
+	return newTokens(c)
+}
+
+func (c *Client) Billing() *Billing {
+	return newBilling(c)
+}
+
+// apiErrors holds the error envelope aiven includes in its responses
+type apiErrors struct {
+	Errors  []Error `json:"errors"`
+	Message string  `json:"message"`
+}
+
+// err returns the first error reported by aiven, if any
+func (a apiErrors) err() error {
+	if len(a.Errors) == 0 {
+		return nil
+	}
+	e := a.Errors[0]
+	if e.Message == "" {
+		e.Message = a.Message
+	}
+	return e
+}
+
+// Error implements error for the errors reported by aiven
+func (e Error) Error() string {
+	return e.Message
+}
+
+// IsUnauthorized returns true if err is aiven rejecting the credentials of the request,
+// i.e. a 401 or 403, as opposed to e.g. a network failure
+func IsUnauthorized(err error) bool {
+	e, ok := errors.Cause(err).(Error)
+	return ok && (e.Status == http.StatusUnauthorized || e.Status == http.StatusForbidden)
+}
+
+// notFound returns true if aiven reported the resource did not exist
+func (a apiErrors) notFound() bool {
+	for _, e := range a.Errors {
+		if e.Status == http.StatusNotFound {
+			return true
+		}
+	}
+	return false
+}
+
 // Do provides a generic handle for request content from aiven
 func (c *Client) Do(ctx context.Context, method, url string, in, out interface{}) error {
 	var body io.Reader
@@ -67,6 +164,36 @@ func (c *Client) Do(ctx context.Context, method, url string, in, out interface{}
 	return nil
 }
 
+// Download writes the raw content of the specified url to w; used for content such as
+// pdfs that is not json
+func (c *Client) Download(ctx context.Context, url string, w io.Writer) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return errors.Wrapf(err, "unable to create request for url, %v", url)
+	}
+	req = req.WithContext(ctx)
+
+	if c.token != "" {
+		req.Header.Set("authorization", "aivenv1 "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "api called failed, %v %v", http.MethodGet, url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("download failed, %v %v: %v", http.MethodGet, url, resp.Status)
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return errors.Wrapf(err, "unable to download content")
+	}
+
+	return nil
+}
+
 // Get specified url with authentication
 func (c *Client) Get(ctx context.Context, url string, out interface{}) error {
 	return c.Do(ctx, http.MethodGet, url, nil, out)
@@ -77,6 +204,11 @@ func (c *Client) Post(ctx context.Context, url string, in, out interface{}) erro
 	return c.Do(ctx, http.MethodPost, url, in, out)
 }
 
+// Put to specified url with authentication
+func (c *Client) Put(ctx context.Context, url string, in, out interface{}) error {
+	return c.Do(ctx, http.MethodPut, url, in, out)
+}
+
 // Delete to specified url with authentication
 func (c *Client) Delete(ctx context.Context, url string, in, out interface{}) error {
 	return c.Do(ctx, http.MethodDelete, url, in, out)
@@ -131,12 +263,32 @@ func NewOTP(email, password, otp string) (*Client, error) {
 	return c, nil
 }
 
+// NewWithToken returns a new aiven client that authenticates with an existing access token
+func NewWithToken(token string) *Client {
+	return &Client{
+		token:  token,
+		client: &http.Client{},
+	}
+}
+
 // New returns a new aiven client with the specified email and password
 func New(email, password string) (*Client, error) {
 	return NewOTP(email, password, "")
 }
 
-// EnvAuth constructs a new client from environment variables: AIVEN_EMAIL, AIVEN_PASSWORD, and AIVEN_OTP
+// EnvAuth constructs a new client from environment variables: AIVEN_TOKEN; or else
+// AIVEN_CREDENTIAL_HELPER, a git style credential helper holding a token under
+// AIVEN_CREDENTIAL_KEY (default "default"); or else AIVEN_EMAIL, AIVEN_PASSWORD, and AIVEN_OTP
 func EnvAuth() (*Client, error) {
+	if token := os.Getenv("AIVEN_TOKEN"); token != "" {
+		return NewWithToken(token), nil
+	}
+	if helper := os.Getenv("AIVEN_CREDENTIAL_HELPER"); helper != "" {
+		key := os.Getenv("AIVEN_CREDENTIAL_KEY")
+		if key == "" {
+			key = "default"
+		}
+		return StoreAuth(NewHelperStore(helper), key)
+	}
 	return NewOTP(os.Getenv("AIVEN_EMAIL"), os.Getenv("AIVEN_PASSWORD"), os.Getenv("AIVEN_OTP"))
 }
@@ -1,6 +1,8 @@
 package aiven_test
 
 import (
+	"context"
+	"net/http"
 	"os"
 	"testing"
 
@@ -35,3 +37,59 @@ func TestAuth(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, client)
 }
+
+func TestMe(t *testing.T) {
+	if os.Getenv("AIVEN_EMAIL") == "" && os.Getenv("AIVEN_TOKEN") == "" {
+		t.Skip("AIVEN_EMAIL not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotEmpty(t, client.Token())
+
+	user, err := client.Me(context.Background())
+	assert.Nil(t, err)
+	assert.NotEmpty(t, user.UserEmail)
+}
+
+func TestIsUnauthorized(t *testing.T) {
+	testCases := map[string]struct {
+		Status int
+		Body   string
+		Want   bool
+	}{
+		"invalid token": {
+			Status: http.StatusUnauthorized,
+			Body:   `{"errors":[{"message":"Invalid token","status":401}],"message":"Invalid token"}`,
+			Want:   true,
+		},
+		"forbidden": {
+			Status: http.StatusForbidden,
+			Body:   `{"errors":[{"status":403}],"message":"Forbidden"}`,
+			Want:   true,
+		},
+		"server error": {
+			Status: http.StatusInternalServerError,
+			Body:   `{"errors":[{"message":"Internal error","status":500}],"message":"Internal error"}`,
+		},
+		"not json": {
+			Status: http.StatusBadGateway,
+			Body:   `<html>bad gateway</html>`,
+		},
+	}
+
+	for label, tc := range testCases {
+		t.Run(label, func(t *testing.T) {
+			fakeAPI(t, func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(tc.Status)
+				w.Write([]byte(tc.Body))
+			})
+
+			_, err := aiven.NewWithToken("token").Me(context.Background())
+			assert.NotNil(t, err)
+			assert.Equal(t, tc.Want, aiven.IsUnauthorized(err))
+		})
+	}
+
+	assert.False(t, aiven.IsUnauthorized(nil))
+}
@@ -0,0 +1,64 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Cloud represents a cloud region services may be created in
+type Cloud struct {
+	CloudDescription string  `json:"cloud_description"`
+	CloudName        string  `json:"cloud_name"`
+	GeoLatitude      float64 `json:"geo_latitude"`
+	GeoLongitude     float64 `json:"geo_longitude"`
+	GeoRegion        string  `json:"geo_region"`
+	Provider         string  `json:"provider"`
+}
+
+// Distance returns the great circle distance in kilometers from the cloud to the
+// specified coordinates
+func (c Cloud) Distance(lat, lon float64) float64 {
+	const earthRadiusKm = 6371
+
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := rad(lat - c.GeoLatitude)
+	dLon := rad(lon - c.GeoLongitude)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(c.GeoLatitude))*math.Cos(rad(lat))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return earthRadiusKm * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// SortCloudsByDistance sorts clouds nearest first relative to the specified coordinates
+func SortCloudsByDistance(clouds []Cloud, lat, lon float64) {
+	sort.SliceStable(clouds, func(i, j int) bool {
+		return clouds[i].Distance(lat, lon) < clouds[j].Distance(lat, lon)
+	})
+}
+
+type CloudsIn struct {
+	Project string
+}
+
+// Clouds returns the clouds available to the project
+//
+// See https://api.aiven.io/doc/#tag/Cloud/operation/ListProjectClouds
+func (c *Client) Clouds(ctx context.Context, in CloudsIn) ([]Cloud, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/clouds", in.Project)
+	out := struct {
+		apiErrors
+		Clouds []Cloud `json:"clouds"`
+	}{}
+	if err := c.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to retrieve clouds for project, %v", in.Project)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to retrieve clouds for project, %v", in.Project)
+	}
+
+	return out.Clouds, nil
+}
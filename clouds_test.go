@@ -0,0 +1,45 @@
+package aiven_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClouds(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	out, err := client.Clouds(context.Background(), aiven.CloudsIn{
+		Project: project,
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestSortCloudsByDistance(t *testing.T) {
+	clouds := []aiven.Cloud{
+		{CloudName: "aws-us-east-1", GeoLatitude: 38.13, GeoLongitude: -78.45},
+		{CloudName: "google-europe-west1", GeoLatitude: 50.45, GeoLongitude: 3.82},
+		{CloudName: "aws-ap-northeast-1", GeoLatitude: 35.68, GeoLongitude: 139.69},
+	}
+
+	// amsterdam
+	aiven.SortCloudsByDistance(clouds, 52.37, 4.90)
+	assert.Equal(t, "google-europe-west1", clouds[0].CloudName)
+	assert.Equal(t, "aws-us-east-1", clouds[1].CloudName)
+	assert.Equal(t, "aws-ap-northeast-1", clouds[2].CloudName)
+
+	assert.InDelta(t, 0, clouds[0].Distance(50.45, 3.82), 0.001)
+	assert.InDelta(t, 226, clouds[0].Distance(52.37, 4.90), 5)
+}
@@ -0,0 +1,280 @@
+package lib
+
+import (
+	"context"
+
+	"github.com/savaki/aiven"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var Account = cli.Command{
+	Name:  "account",
+	Usage: "account and team related commands",
+	Subcommands: cli.Commands{
+		{
+			Name:  "list",
+			Usage: "list accounts",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+			},
+			Action: Do(listAccounts, "account_id", "account_name", "create_time"),
+		},
+		{
+			Name:  "team",
+			Usage: "manage account teams",
+			Subcommands: cli.Commands{
+				{
+					Name:  "list",
+					Usage: "list teams",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagAccountID,
+					},
+					Action: Do(listTeams, "team_id", "team_name", "create_time"),
+				},
+				{
+					Name:  "create",
+					Usage: "create team",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagAccountID,
+						flagTeamName,
+					},
+					Action: Do(createTeam),
+				},
+				{
+					Name:  "delete",
+					Usage: "delete team",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagAccountID,
+						flagTeamID,
+					},
+					Action: Do(deleteTeam),
+				},
+				{
+					Name:  "members",
+					Usage: "list team members and pending invites",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagAccountID,
+						flagTeamID,
+					},
+					Action: Do(listTeamMembers),
+				},
+				{
+					Name:  "add-member",
+					Usage: "invite user to team",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagAccountID,
+						flagTeamID,
+						flagMemberEmail,
+					},
+					Action: Do(addTeamMember),
+				},
+				{
+					Name:  "remove-member",
+					Usage: "remove user from team",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagAccountID,
+						flagTeamID,
+						flagTeamUserID,
+					},
+					Action: Do(removeTeamMember),
+				},
+				{
+					Name:  "projects",
+					Usage: "list projects team has access to",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagAccountID,
+						flagTeamID,
+					},
+					Action: Do(listTeamProjects),
+				},
+				{
+					Name:  "attach",
+					Usage: "grant team access to project",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagAccountID,
+						flagTeamID,
+						flagProject,
+						flagTeamType,
+					},
+					Action: Do(attachTeam),
+				},
+				{
+					Name:  "detach",
+					Usage: "revoke team access to project",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagAccountID,
+						flagTeamID,
+						flagProject,
+					},
+					Action: Do(detachTeam),
+				},
+			},
+		},
+	},
+}
+
+func listAccounts(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Accounts().List(ctx)
+}
+
+func listTeams(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Accounts().ListTeams(ctx, aiven.TeamListIn{
+		AccountID: opts.AccountID,
+	})
+}
+
+func createTeam(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Accounts().CreateTeam(ctx, aiven.TeamCreateIn{
+		AccountID: opts.AccountID,
+		TeamName:  opts.Team.Name,
+	})
+}
+
+func deleteTeam(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Accounts().DeleteTeam(ctx, aiven.TeamDeleteIn{
+		AccountID: opts.AccountID,
+		TeamID:    opts.Team.ID,
+	})
+}
+
+func listTeamMembers(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	in := aiven.TeamMembersIn{
+		AccountID: opts.AccountID,
+		TeamID:    opts.Team.ID,
+	}
+	members, err := client.Accounts().ListTeamMembers(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	invites, err := client.Accounts().ListTeamInvites(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return struct {
+		Invites []aiven.TeamInvite `json:"invites"`
+		Members []aiven.TeamMember `json:"members"`
+	}{
+		Invites: invites,
+		Members: members,
+	}, nil
+}
+
+func addTeamMember(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Accounts().AddTeamMember(ctx, aiven.TeamAddMemberIn{
+		AccountID: opts.AccountID,
+		TeamID:    opts.Team.ID,
+		Email:     opts.Member.Email,
+	})
+}
+
+func removeTeamMember(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Accounts().RemoveTeamMember(ctx, aiven.TeamRemoveMemberIn{
+		AccountID: opts.AccountID,
+		TeamID:    opts.Team.ID,
+		UserID:    opts.Team.UserID,
+	})
+}
+
+func listTeamProjects(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Accounts().ListTeamProjects(ctx, aiven.TeamProjectsIn{
+		AccountID: opts.AccountID,
+		TeamID:    opts.Team.ID,
+	})
+}
+
+func attachTeam(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Accounts().AttachProject(ctx, aiven.TeamAttachProjectIn{
+		AccountID: opts.AccountID,
+		TeamID:    opts.Team.ID,
+		Project:   opts.Project,
+		TeamType:  aiven.MemberType(opts.Team.Type),
+	})
+}
+
+func detachTeam(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Accounts().DetachProject(ctx, aiven.TeamDetachProjectIn{
+		AccountID: opts.AccountID,
+		TeamID:    opts.Team.ID,
+		Project:   opts.Project,
+	})
+}
@@ -0,0 +1,325 @@
+package lib
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/savaki/aiven"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var Billing = cli.Command{
+	Name:  "billing",
+	Usage: "invoice, credit and cost related commands",
+	Subcommands: cli.Commands{
+		{
+			Name:  "invoices",
+			Usage: "list invoices",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+			},
+			Action: Do(listInvoices, "invoice_number", "period_begin", "period_end", "state", "total_inc_vat", "currency"),
+		},
+		{
+			Name:  "lines",
+			Usage: "list charges of invoice",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagInvoice,
+			},
+			Action: Do(listInvoiceLines),
+		},
+		{
+			Name:  "download",
+			Usage: "download invoice pdf",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagInvoice,
+				flagSaveAs,
+			},
+			Action: Do(downloadInvoice),
+		},
+		{
+			Name:  "credits",
+			Usage: "list credits",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+			},
+			Action: Do(listCredits, "code", "type", "value", "remaining_value", "expire_time"),
+		},
+		{
+			Name:  "report",
+			Usage: "cost per service for month; estimated for the current month, from invoices otherwise",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProjects,
+				flagMonth,
+				flagReportFormat,
+				flagReportTimeout,
+			},
+			Action: Do(billingReport, "project", "service", "service_type", "plan", "cloud", "description", "hours", "cost_usd", "approximate", "source"),
+		},
+	},
+}
+
+func listInvoices(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Billing().Invoices(ctx, aiven.BillingInvoicesIn{
+		Project: opts.Project,
+	})
+}
+
+// findInvoice returns the invoice of the project matching fn
+func findInvoice(ctx context.Context, client *aiven.Client, project string, fn func(aiven.Invoice) bool) (aiven.Invoice, bool, error) {
+	invoices, err := client.Billing().Invoices(ctx, aiven.BillingInvoicesIn{
+		Project: project,
+	})
+	if err != nil {
+		return aiven.Invoice{}, false, err
+	}
+
+	for _, invoice := range invoices {
+		if fn(invoice) {
+			return invoice, true, nil
+		}
+	}
+
+	return aiven.Invoice{}, false, nil
+}
+
+func invoiceLines(ctx context.Context, client *aiven.Client, project, invoiceNumber string) ([]aiven.InvoiceLine, error) {
+	p, err := client.Projects().Get(ctx, aiven.ProjectGetIn{
+		Project: project,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Billing().InvoiceLines(ctx, aiven.BillingInvoiceLinesIn{
+		BillingGroupID: p.BillingGroupID,
+		InvoiceNumber:  invoiceNumber,
+	})
+}
+
+func listInvoiceLines(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return invoiceLines(ctx, client, opts.Project, opts.Billing.Invoice)
+}
+
+func downloadInvoice(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	invoice, ok, err := findInvoice(ctx, client, opts.Project, func(invoice aiven.Invoice) bool {
+		return invoice.InvoiceNumber == opts.Billing.Invoice
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Errorf("invoice, %v, not found in project, %v", opts.Billing.Invoice, opts.Project)
+	}
+
+	filename := opts.Billing.SaveAs
+	if filename == "" {
+		filename = invoice.InvoiceNumber + ".pdf"
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to create file, %v", filename)
+	}
+	defer f.Close()
+
+	err = client.Billing().DownloadInvoice(ctx, aiven.BillingDownloadInvoiceIn{
+		Project:        opts.Project,
+		InvoiceNumber:  invoice.InvoiceNumber,
+		DownloadCookie: invoice.DownloadCookie,
+	}, f)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, f.Close()
+}
+
+func listCredits(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Billing().Credits(ctx, aiven.BillingCreditsIn{
+		Project: opts.Project,
+	})
+}
+
+// reportRow is the cost of a single service, or other charge, within the report
+type reportRow struct {
+	Project     string  `json:"project"`
+	Service     string  `json:"service"`
+	ServiceType string  `json:"service_type"`
+	Plan        string  `json:"plan"`
+	Cloud       string  `json:"cloud"`
+	Description string  `json:"description"`
+	Hours       float64 `json:"hours"`
+	CostUSD     float64 `json:"cost_usd"`
+	Approximate bool    `json:"approximate"`
+	Source      string  `json:"source"`
+}
+
+// month parses the --month flag
+func month() (time.Time, error) {
+	if opts.Billing.Month == "" {
+		return time.Now().UTC(), nil
+	}
+
+	t, err := time.Parse("2006-01", opts.Billing.Month)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid month, %v; expected e.g. 2026-09", opts.Billing.Month)
+	}
+	return t, nil
+}
+
+func estimatedRows(ctx context.Context, client *aiven.Client, project string, t time.Time) ([]reportRow, error) {
+	costs, err := client.Billing().EstimateCosts(ctx, aiven.BillingEstimateIn{
+		Project: project,
+		Month:   t,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []reportRow
+	for _, cost := range costs {
+		description := "estimated from plan price"
+		switch {
+		case !cost.Priced:
+			description = "plan price unavailable"
+		case cost.Approximate:
+			description = "powered off; hours run before power off unknown and not included"
+		}
+		rows = append(rows, reportRow{
+			Project:     project,
+			Service:     cost.Service,
+			ServiceType: cost.ServiceType,
+			Plan:        cost.Plan,
+			Cloud:       cost.Cloud,
+			Description: description,
+			Hours:       cost.Hours,
+			CostUSD:     cost.CostUSD,
+			Approximate: cost.Approximate || !cost.Priced,
+			Source:      "estimate",
+		})
+	}
+
+	return rows, nil
+}
+
+func invoicedRows(ctx context.Context, client *aiven.Client, project string, t time.Time) ([]reportRow, error) {
+	invoice, ok, err := findInvoice(ctx, client, project, func(invoice aiven.Invoice) bool {
+		begin := invoice.PeriodBegin.UTC()
+		return begin.Year() == t.Year() && begin.Month() == t.Month()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Errorf("no invoice for %v in project, %v", t.Format("2006-01"), project)
+	}
+
+	lines, err := invoiceLines(ctx, client, project, invoice.InvoiceNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []reportRow
+	for _, line := range lines {
+		var hours float64
+		if line.TimestampBegin != nil && line.TimestampEnd != nil {
+			hours = line.TimestampEnd.Sub(*line.TimestampBegin).Hours()
+		}
+		cost, _ := strconv.ParseFloat(line.LineTotalUSD, 64)
+
+		rows = append(rows, reportRow{
+			Project:     project,
+			Service:     line.ServiceName,
+			ServiceType: line.ServiceType,
+			Plan:        line.ServicePlan,
+			Cloud:       line.CloudName,
+			Description: line.Description,
+			Hours:       hours,
+			CostUSD:     cost,
+			Source:      "invoice " + invoice.InvoiceNumber,
+		})
+	}
+
+	return rows, nil
+}
+
+func billingReport(ctx context.Context) (interface{}, error) {
+	// --format predates the global output flags and is kept as an alias of --output
+	if opts.Billing.Format != "" {
+		opts.Output.Format = opts.Billing.Format
+	}
+
+	names, err := projects()
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := month()
+	if err != nil {
+		return nil, err
+	}
+	_, end := aiven.MonthPeriod(t)
+	current := time.Now().Before(end)
+
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []reportRow
+	for _, project := range names {
+		var projectRows []reportRow
+		if current {
+			projectRows, err = estimatedRows(ctx, client, project, t)
+		} else {
+			projectRows, err = invoicedRows(ctx, client, project, t)
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, projectRows...)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,71 @@
+package lib
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// runApp runs the billing commands with args and returns what was written to stdout
+func runApp(t *testing.T, args ...string) string {
+	r, w, err := os.Pipe()
+	assert.Nil(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	app := cli.NewApp()
+	app.Flags = GlobalFlags
+	app.Commands = cli.Commands{Billing}
+	assert.Nil(t, app.Run(append([]string{"aiven"}, args...)))
+
+	w.Close()
+	data, err := ioutil.ReadAll(r)
+	assert.Nil(t, err)
+	return string(data)
+}
+
+func TestBillingReportFormat(t *testing.T) {
+	routes := map[string]fakeResponse{
+		"/v1beta/project/project/invoice": {http.StatusOK, `{"invoices":[{"invoice_number":"inv-1","period_begin":"2026-09-01T00:00:00Z","period_end":"2026-09-30T23:59:59Z"}]}`},
+		"/v1beta/project/project":         {http.StatusOK, `{"project":{"project_name":"project","billing_group_id":"bg-1"}}`},
+		"/v1beta/billing-group/bg-1/invoice/inv-1/lines": {http.StatusOK, `{"lines":[{"service_name":"db","service_type":"pg","service_plan":"business-4","cloud_name":"google-europe-west1",
+			"description":"db usage","line_total_usd":"12.50","timestamp_begin":"2026-09-01T00:00:00Z","timestamp_end":"2026-09-01T10:00:00Z"}]}`},
+	}
+	csv := "project,service,service_type,plan,cloud,description,hours,cost_usd,approximate,source\n" +
+		"project,db,pg,business-4,google-europe-west1,db usage,10,12.5,false,invoice inv-1\n"
+
+	testCases := map[string]struct {
+		Args []string
+		Want string
+	}{
+		"format": {
+			Args: []string{"billing", "report", "--format", "csv", "--project", "project", "--month", "2026-09"},
+			Want: csv,
+		},
+		"short format after the subcommand": {
+			Args: []string{"billing", "report", "-o", "csv", "--project", "project", "--month", "2026-09"},
+			Want: csv,
+		},
+		"global output": {
+			Args: []string{"-o", "csv", "billing", "report", "--project", "project", "--month", "2026-09"},
+			Want: csv,
+		},
+	}
+
+	for label, tc := range testCases {
+		t.Run(label, func(t *testing.T) {
+			withConfig(t, "")
+			fakeRoutes(t, routes)
+			t.Setenv("AIVEN_TOKEN", "token")
+			defer func() { opts.Billing.Format = "" }()
+
+			assert.Equal(t, tc.Want, runApp(t, tc.Args...))
+		})
+	}
+}
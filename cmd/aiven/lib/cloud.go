@@ -0,0 +1,89 @@
+package lib
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/savaki/aiven"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var Cloud = cli.Command{
+	Name:  "cloud",
+	Usage: "cloud related commands",
+	Subcommands: cli.Commands{
+		{
+			Name:  "list",
+			Usage: "list clouds available to project",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagNear,
+			},
+			Action: Do(listClouds, "cloud_name", "provider", "geo_region", "cloud_description"),
+		},
+	},
+}
+
+// cloud decorates an aiven cloud with its distance from --near
+type cloud struct {
+	aiven.Cloud
+	DistanceKm float64 `json:"distance_km,omitempty"`
+}
+
+// parseLatLon parses coordinates in the form lat,lon
+func parseLatLon(s string) (lat, lon float64, err error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return 0, 0, errors.Errorf("invalid coordinates, %v; expected lat,lon", s)
+	}
+
+	lat, err = strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid latitude, %v", parts[0])
+	}
+	lon, err = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid longitude, %v", parts[1])
+	}
+
+	return lat, lon, nil
+}
+
+func listClouds(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	clouds, err := client.Clouds(ctx, aiven.CloudsIn{
+		Project: opts.Project,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Near == "" {
+		return clouds, nil
+	}
+
+	lat, lon, err := parseLatLon(opts.Near)
+	if err != nil {
+		return nil, err
+	}
+
+	aiven.SortCloudsByDistance(clouds, lat, lon)
+
+	var out []cloud
+	for _, c := range clouds {
+		out = append(out, cloud{
+			Cloud:      c,
+			DistanceKm: c.Distance(lat, lon),
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,396 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gopkg.in/urfave/cli.v1"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	AuthToken    = "token"
+	AuthPassword = "password"
+)
+
+// Profile holds the defaults applied to commands run with the profile. Passwords and
+// one time passwords are never stored; with password auth they still come from the
+// command line or environment. Tokens are kept in the credential store; Token is only
+// present in config files written before then and is moved there when the profile is
+// used.
+type Profile struct {
+	Auth    string `yaml:"auth,omitempty"`
+	Email   string `yaml:"email,omitempty"`
+	Token   string `yaml:"token,omitempty"`
+	Project string `yaml:"project,omitempty"`
+	Service string `yaml:"service,omitempty"`
+}
+
+// Config is the content of the cli configuration file. CredentialStore selects where
+// tokens are kept: file, helper (a git style credential helper named by
+// CredentialHelper) or encrypted.
+type Config struct {
+	Active           string             `yaml:"active,omitempty"`
+	CredentialStore  string             `yaml:"credential_store,omitempty"`
+	CredentialHelper string             `yaml:"credential_helper,omitempty"`
+	Profiles         map[string]Profile `yaml:"profiles,omitempty"`
+}
+
+var Configure = cli.Command{
+	Name:  "config",
+	Usage: "manage named profiles in ~/.config/aiven/config.yaml",
+	Subcommands: cli.Commands{
+		{
+			Name:   "list",
+			Usage:  "list profiles",
+			Action: Do(listProfiles, "name", "active", "auth", "email", "project", "service"),
+		},
+		{
+			Name:      "use",
+			Usage:     "make profile the active profile",
+			ArgsUsage: "<profile>",
+			Action:    withArgs(useProfile),
+		},
+		{
+			Name:      "set",
+			Usage:     "set profile field; one of auth, email, token, project or service. The token value must be -, which reads the token from stdin",
+			ArgsUsage: "<profile> <field> <value>",
+			Action:    withArgs(setProfile),
+		},
+		{
+			Name:      "credential-store",
+			Usage:     "select where tokens are kept; file, helper or encrypted",
+			ArgsUsage: "<file|helper|encrypted> [helper]",
+			Action:    withArgs(setCredentialStore),
+		},
+	},
+}
+
+// configDir returns the directory holding the cli configuration
+func configDir() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "aiven")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".config", "aiven")
+	}
+	return filepath.Join(home, ".config", "aiven")
+}
+
+// configFile returns the path of the configuration file; AIVEN_CONFIG overrides the default
+func configFile() string {
+	if filename := os.Getenv("AIVEN_CONFIG"); filename != "" {
+		return filename
+	}
+	return filepath.Join(configDir(), "config.yaml")
+}
+
+// loadConfig reads the configuration file; a missing file is an empty configuration
+func loadConfig() (Config, error) {
+	var config Config
+
+	data, err := ioutil.ReadFile(configFile())
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return Config{}, errors.Wrapf(err, "unable to read config, %v", configFile())
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return Config{}, errors.Wrapf(err, "unable to parse config, %v", configFile())
+	}
+
+	return config, nil
+}
+
+// saveConfig writes the configuration file readable only by the current user as it
+// may contain tokens
+func saveConfig(config Config) error {
+	filename := configFile()
+	if err := os.MkdirAll(filepath.Dir(filename), 0700); err != nil {
+		return errors.Wrapf(err, "unable to create config dir, %v", filepath.Dir(filename))
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrapf(err, "unable to encode config")
+	}
+	if err := ioutil.WriteFile(filename, data, 0600); err != nil {
+		return errors.Wrapf(err, "unable to write config, %v", filename)
+	}
+
+	return nil
+}
+
+// profileApplied ensures the profile is only applied once per invocation
+var profileApplied bool
+
+// applyProfile fills in the options not set on the command line or environment from
+// the profile selected by --profile, or else the active profile
+func applyProfile() error {
+	if profileApplied {
+		return nil
+	}
+	profileApplied = true
+
+	if err := readToken(); err != nil {
+		return err
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	name := opts.Profile
+	if name == "" {
+		name = config.Active
+	}
+	if name == "" {
+		return nil
+	}
+
+	profile, ok := config.Profiles[name]
+	if !ok {
+		return errors.Errorf("profile, %v, not found in %v", name, configFile())
+	}
+
+	// tokens were once kept in the config file; move them to the credential store
+	if profile.Token != "" {
+		if err := migrateProfileToken(config, name); err != nil {
+			return err
+		}
+	}
+
+	if opts.Token == "" && opts.Email == "" {
+		if profile.Auth != AuthPassword && profile.Token == "" {
+			token, _, err := storedSecret(fmt.Sprintf(profileKeyFmt, name))
+			if err != nil {
+				return err
+			}
+			profile.Token = token
+		}
+
+		switch profile.Auth {
+		case AuthToken:
+			opts.Token = profile.Token
+		case AuthPassword:
+			opts.Email = profile.Email
+		default:
+			opts.Token = profile.Token
+			opts.Email = profile.Email
+		}
+	}
+	if opts.Project == "" {
+		opts.Project = profile.Project
+	}
+	if opts.Service == "" {
+		opts.Service = profile.Service
+	}
+
+	return nil
+}
+
+// readToken sets the token from AIVEN_TOKEN or, given --token -, from stdin. Tokens are
+// not accepted as arguments as they would be left in shell history.
+func readToken() error {
+	switch opts.TokenArg {
+	case "":
+		if opts.Token == "" {
+			opts.Token = os.Getenv("AIVEN_TOKEN")
+		}
+	case "-":
+		token, err := promptHidden(stdinReader(), "Token: ")
+		if err != nil {
+			return err
+		}
+		if token == "" {
+			return errors.New("no token given")
+		}
+		opts.Token = token
+	default:
+		return errors.New("tokens are not accepted as arguments; use --token - to read the token from stdin or set AIVEN_TOKEN")
+	}
+	return nil
+}
+
+// migrateProfileToken moves the token of the named profile from the config file to the
+// credential store
+func migrateProfileToken(config Config, name string) error {
+	profile := config.Profiles[name]
+
+	s, err := credentialStore()
+	if err != nil {
+		return err
+	}
+	if err := s.Set(fmt.Sprintf(profileKeyFmt, name), profile.Token); err != nil {
+		return errors.Wrapf(err, "unable to move token of profile, %v, to the credential store", name)
+	}
+
+	profile.Token = ""
+	config.Profiles[name] = profile
+	return saveConfig(config)
+}
+
+// withArgs adapts a command that takes positional arguments to Do
+func withArgs(fn func(args cli.Args) (interface{}, error), columns ...string) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		return Do(func(context.Context) (interface{}, error) {
+			return fn(c.Args())
+		}, columns...)(c)
+	}
+}
+
+func listProfiles(context.Context) (interface{}, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	type profileSummary struct {
+		Name    string `json:"name"`
+		Active  bool   `json:"active"`
+		Auth    string `json:"auth,omitempty"`
+		Email   string `json:"email,omitempty"`
+		Token   string `json:"token,omitempty"`
+		Project string `json:"project,omitempty"`
+		Service string `json:"service,omitempty"`
+	}
+
+	var names []string
+	for name := range config.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	summaries := []profileSummary{}
+	for _, name := range names {
+		profile := config.Profiles[name]
+		token := ""
+		if profile.Token != "" {
+			token = "****"
+		}
+		summaries = append(summaries, profileSummary{
+			Name:    name,
+			Active:  name == config.Active,
+			Auth:    profile.Auth,
+			Email:   profile.Email,
+			Token:   token,
+			Project: profile.Project,
+			Service: profile.Service,
+		})
+	}
+
+	return summaries, nil
+}
+
+func useProfile(args cli.Args) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, errors.New("usage: aiven config use <profile>")
+	}
+	name := args[0]
+
+	config, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := config.Profiles[name]; !ok {
+		return nil, errors.Errorf("profile, %v, not found in %v", name, configFile())
+	}
+
+	config.Active = name
+	return nil, saveConfig(config)
+}
+
+func setProfile(args cli.Args) (interface{}, error) {
+	if len(args) != 3 {
+		return nil, errors.New("usage: aiven config set <profile> <field> <value>")
+	}
+	name, field, value := args[0], args[1], args[2]
+
+	config, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if config.Profiles == nil {
+		config.Profiles = map[string]Profile{}
+	}
+
+	profile := config.Profiles[name]
+	switch field {
+	case "auth":
+		if value != AuthToken && value != AuthPassword {
+			return nil, errors.Errorf("invalid auth, %v; expected %v or %v", value, AuthToken, AuthPassword)
+		}
+		profile.Auth = value
+	case "email":
+		profile.Email = value
+	case "token":
+		// a token given as an argument would be left in shell history
+		if value != "-" {
+			return nil, errors.New("tokens are not accepted as arguments; use - to read the token from stdin e.g. aiven config set <profile> token -")
+		}
+		// resolve the store first as an encrypted store prompts for its passphrase
+		s, err := credentialStore()
+		if err != nil {
+			return nil, err
+		}
+		value, err = promptHidden(stdinReader(), "Token: ")
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			return nil, errors.New("no token given")
+		}
+		if err := s.Set(fmt.Sprintf(profileKeyFmt, name), value); err != nil {
+			return nil, err
+		}
+		profile.Token = ""
+	case "project":
+		profile.Project = value
+	case "service":
+		profile.Service = value
+	default:
+		return nil, errors.Errorf("unknown field, %v; expected auth, email, token, project or service", field)
+	}
+	config.Profiles[name] = profile
+
+	// the first profile becomes active so it takes effect without a separate use
+	if config.Active == "" {
+		config.Active = name
+	}
+
+	return nil, saveConfig(config)
+}
+
+func setCredentialStore(args cli.Args) (interface{}, error) {
+	if len(args) == 0 {
+		return nil, errors.New("usage: aiven config credential-store <file|helper|encrypted> [helper]")
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	switch args[0] {
+	case StoreFile, StoreEncrypted:
+		config.CredentialHelper = ""
+	case StoreHelper:
+		if len(args) != 2 {
+			return nil, errors.New("usage: aiven config credential-store helper <helper> e.g. osxkeychain")
+		}
+		config.CredentialHelper = args[1]
+	default:
+		return nil, errors.Errorf("unknown credential store, %v; expected %v, %v or %v", args[0], StoreFile, StoreHelper, StoreEncrypted)
+	}
+	config.CredentialStore = args[0]
+
+	return nil, saveConfig(config)
+}
@@ -0,0 +1,284 @@
+package lib
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// withConfig points the configuration, and the credentials kept alongside it, at a temp
+// dir holding config and resets the options and state applyProfile depends on
+func withConfig(t *testing.T, config string) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("AIVEN_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("AIVEN_CREDENTIAL_HELPER", "")
+	t.Setenv("AIVEN_CREDENTIAL_PASSPHRASE", "")
+	t.Setenv("AIVEN_TOKEN", "")
+
+	if config != "" {
+		if err := ioutil.WriteFile(filepath.Join(dir, "config.yaml"), []byte(config), 0600); err != nil {
+			t.Fatalf("unable to write config: %v", err)
+		}
+	}
+
+	original := opts
+	t.Cleanup(func() {
+		opts = original
+		profileApplied = false
+		store = nil
+		stdin = nil
+	})
+	opts.Email, opts.Token, opts.TokenArg, opts.Profile, opts.Project, opts.Service = "", "", "", "", "", ""
+	profileApplied = false
+	store = nil
+	stdin = nil
+}
+
+const testConfig = `
+active: dev
+profiles:
+  dev:
+    email: dev@example.com
+    project: dev-project
+    service: dev-service
+  prod:
+    auth: token
+    email: ops@example.com
+    project: prod-project
+  ci:
+    project: ci-project
+  audit:
+    auth: password
+    email: audit@example.com
+    project: audit-project
+`
+
+func TestApplyProfile(t *testing.T) {
+	testCases := map[string]struct {
+		Profile string
+		Email   string
+		Token   string
+		Project string
+
+		WantEmail   string
+		WantToken   string
+		WantProject string
+		WantService string
+	}{
+		"active profile": {
+			WantEmail:   "dev@example.com",
+			WantToken:   "dev-token",
+			WantProject: "dev-project",
+			WantService: "dev-service",
+		},
+		"profile flag over active": {
+			Profile:     "prod",
+			WantToken:   "prod-token",
+			WantProject: "prod-project",
+		},
+		"flag over profile": {
+			Project:     "other-project",
+			WantEmail:   "dev@example.com",
+			WantToken:   "dev-token",
+			WantProject: "other-project",
+			WantService: "dev-service",
+		},
+		"token flag over profile credentials": {
+			Token:       "flag-token",
+			WantToken:   "flag-token",
+			WantProject: "dev-project",
+			WantService: "dev-service",
+		},
+		"email flag over profile credentials": {
+			Email:       "me@example.com",
+			WantEmail:   "me@example.com",
+			WantProject: "dev-project",
+			WantService: "dev-service",
+		},
+		"auth token ignores email": {
+			Profile:     "prod",
+			WantToken:   "prod-token",
+			WantProject: "prod-project",
+		},
+		"default auth without email uses token": {
+			Profile:     "ci",
+			WantToken:   "ci-token",
+			WantProject: "ci-project",
+		},
+		"auth password ignores token": {
+			Profile:     "audit",
+			WantEmail:   "audit@example.com",
+			WantProject: "audit-project",
+		},
+	}
+
+	for label, tc := range testCases {
+		t.Run(label, func(t *testing.T) {
+			withConfig(t, testConfig)
+
+			s, err := credentialStore()
+			assert.Nil(t, err)
+			for _, name := range []string{"dev", "prod", "ci", "audit"} {
+				assert.Nil(t, s.Set(fmt.Sprintf(profileKeyFmt, name), name+"-token"))
+			}
+
+			opts.Profile = tc.Profile
+			opts.Email = tc.Email
+			opts.Token = tc.Token
+			opts.Project = tc.Project
+
+			assert.Nil(t, applyProfile())
+			assert.Equal(t, tc.WantEmail, opts.Email)
+			assert.Equal(t, tc.WantToken, opts.Token)
+			assert.Equal(t, tc.WantProject, opts.Project)
+			assert.Equal(t, tc.WantService, opts.Service)
+		})
+	}
+}
+
+func TestApplyProfileErrors(t *testing.T) {
+	t.Run("unknown profile", func(t *testing.T) {
+		withConfig(t, testConfig)
+		opts.Profile = "missing"
+		assert.NotNil(t, applyProfile())
+	})
+
+	t.Run("no config", func(t *testing.T) {
+		withConfig(t, "")
+		assert.Nil(t, applyProfile())
+		assert.Equal(t, "", opts.Project)
+	})
+
+	t.Run("applied once", func(t *testing.T) {
+		withConfig(t, testConfig)
+		assert.Nil(t, applyProfile())
+
+		opts.Project = ""
+		assert.Nil(t, applyProfile())
+		assert.Equal(t, "", opts.Project)
+	})
+}
+
+func TestSetProfile(t *testing.T) {
+	withConfig(t, "")
+
+	_, err := setProfile(cli.Args{"dev", "project", "dev-project"})
+	assert.Nil(t, err)
+
+	config, err := loadConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, "dev", config.Active, "first profile becomes active")
+
+	_, err = setProfile(cli.Args{"prod", "project", "prod-project"})
+	assert.Nil(t, err)
+	_, err = setProfile(cli.Args{"prod", "auth", "token"})
+	assert.Nil(t, err)
+
+	config, err = loadConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, "dev", config.Active, "later profiles do not replace the active profile")
+	assert.Equal(t, Profile{Auth: AuthToken, Project: "prod-project"}, config.Profiles["prod"])
+
+	// tokens given as arguments would be left in shell history
+	_, err = setProfile(cli.Args{"prod", "token", "secret-token"})
+	assert.NotNil(t, err)
+	_, err = setProfile(cli.Args{"prod", "auth", "sso"})
+	assert.NotNil(t, err)
+	_, err = setProfile(cli.Args{"prod", "colour", "blue"})
+	assert.NotNil(t, err)
+
+	info, err := os.Stat(configFile())
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+// withStdin replaces stdin with input for the duration of the test
+func withStdin(t *testing.T, input string) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("unable to create pipe: %v", err)
+	}
+	w.WriteString(input)
+	w.Close()
+
+	original := os.Stdin
+	os.Stdin = r
+	stdin = nil
+	t.Cleanup(func() {
+		os.Stdin = original
+		stdin = nil
+		r.Close()
+	})
+}
+
+func TestSetProfileTokenFromStdin(t *testing.T) {
+	withConfig(t, "credential_store: encrypted\n")
+	withStdin(t, "passphrase\nsecret-token\n")
+
+	// the passphrase and token are read from one stream in the order prompted
+	_, err := setProfile(cli.Args{"prod", "token", "-"})
+	assert.Nil(t, err)
+
+	s := aiven.NewEncryptedFileStore(filepath.Join(configDir(), "credentials.enc"), []byte("passphrase"))
+	token, err := s.Get(fmt.Sprintf(profileKeyFmt, "prod"))
+	assert.Nil(t, err)
+	assert.Equal(t, "secret-token", token)
+}
+
+func TestReadToken(t *testing.T) {
+	t.Run("stdin", func(t *testing.T) {
+		withConfig(t, "")
+		withStdin(t, "stdin-token\n")
+		t.Setenv("AIVEN_TOKEN", "env-token")
+		opts.TokenArg = "-"
+
+		assert.Nil(t, applyProfile())
+		assert.Equal(t, "stdin-token", opts.Token)
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		withConfig(t, "")
+		t.Setenv("AIVEN_TOKEN", "env-token")
+
+		assert.Nil(t, applyProfile())
+		assert.Equal(t, "env-token", opts.Token)
+	})
+
+	t.Run("argument", func(t *testing.T) {
+		withConfig(t, "")
+		opts.TokenArg = "secret-token"
+
+		assert.NotNil(t, applyProfile(), "tokens given as arguments would be left in shell history")
+		assert.Equal(t, "", opts.Token)
+	})
+}
+
+func TestApplyProfileMigratesToken(t *testing.T) {
+	withConfig(t, `
+active: legacy
+profiles:
+  legacy:
+    token: legacy-token
+    project: legacy-project
+`)
+
+	assert.Nil(t, applyProfile())
+	assert.Equal(t, "legacy-token", opts.Token)
+
+	config, err := loadConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, "", config.Profiles["legacy"].Token, "token scrubbed from config")
+	assert.Equal(t, "legacy-project", config.Profiles["legacy"].Project)
+
+	token, ok, err := storedSecret(fmt.Sprintf(profileKeyFmt, "legacy"))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "legacy-token", token)
+}
@@ -0,0 +1,115 @@
+package lib
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/savaki/aiven"
+)
+
+const (
+	StoreFile      = "file"
+	StoreHelper    = "helper"
+	StoreEncrypted = "encrypted"
+)
+
+// keys under which the cli keeps secrets in the credential store
+const (
+	sessionKey    = "session"
+	profileKeyFmt = "profile:%v"
+)
+
+// store caches the credential store so an encrypted store only asks for its passphrase once
+var store aiven.CredentialStore
+
+// credentialStore returns the credential store selected in the config file. When none
+// is selected, the helper named by AIVEN_CREDENTIAL_HELPER is used, or else an encrypted
+// file when AIVEN_CREDENTIAL_PASSPHRASE is set, or else a plain file readable only by
+// the current user, which warns when it is first written.
+func credentialStore() (aiven.CredentialStore, error) {
+	if store != nil {
+		return store, nil
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	kind, helper := config.CredentialStore, config.CredentialHelper
+	if kind == "" {
+		switch {
+		case os.Getenv("AIVEN_CREDENTIAL_HELPER") != "":
+			kind, helper = StoreHelper, os.Getenv("AIVEN_CREDENTIAL_HELPER")
+		case os.Getenv("AIVEN_CREDENTIAL_PASSPHRASE") != "":
+			kind = StoreEncrypted
+		}
+	}
+
+	switch kind {
+	case "":
+		filename := filepath.Join(configDir(), "credentials.json")
+		store = plaintextStore{
+			FileStore: aiven.NewFileStore(filename),
+			path:      filename,
+		}
+
+	case StoreFile:
+		store = aiven.NewFileStore(filepath.Join(configDir(), "credentials.json"))
+
+	case StoreHelper:
+		if helper == "" {
+			return nil, errors.Errorf("credential_helper not set in %v", configFile())
+		}
+		store = aiven.NewHelperStore(helper)
+
+	case StoreEncrypted:
+		passphrase := os.Getenv("AIVEN_CREDENTIAL_PASSPHRASE")
+		if passphrase == "" {
+			v, err := promptHidden(stdinReader(), "Credential store passphrase: ")
+			if err != nil {
+				return nil, err
+			}
+			passphrase = v
+		}
+		store = aiven.NewEncryptedFileStore(filepath.Join(configDir(), "credentials.enc"), []byte(passphrase))
+
+	default:
+		return nil, errors.Errorf("unknown credential_store, %v, in %v; expected %v, %v or %v", config.CredentialStore, configFile(), StoreFile, StoreHelper, StoreEncrypted)
+	}
+
+	return store, nil
+}
+
+// plaintextStore is the file store used when no credential store was selected; it warns
+// before a secret is first written to disk in plaintext
+type plaintextStore struct {
+	*aiven.FileStore
+	path string
+}
+
+func (p plaintextStore) Set(key, secret string) error {
+	if _, err := os.Stat(p.path); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "warning: saving token in plaintext file, %v; use aiven config credential-store helper <helper> or encrypted to keep tokens in a keychain or encrypted file instead\n", p.path)
+	}
+	return p.FileStore.Set(key, secret)
+}
+
+// storedSecret returns the secret held under key; ok is false if there is none
+func storedSecret(key string) (secret string, ok bool, err error) {
+	s, err := credentialStore()
+	if err != nil {
+		return "", false, err
+	}
+
+	secret, err = s.Get(key)
+	if errors.Cause(err) == aiven.ErrCredentialNotFound {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return secret, true, nil
+}
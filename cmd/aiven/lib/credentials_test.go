@@ -0,0 +1,79 @@
+package lib
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentialStore(t *testing.T) {
+	testCases := map[string]struct {
+		Config     string
+		Helper     string
+		Passphrase string
+		Want       aiven.CredentialStore
+	}{
+		"default warns": {
+			Want: plaintextStore{},
+		},
+		"file selected": {
+			Config: "credential_store: file",
+			Want:   &aiven.FileStore{},
+		},
+		"helper selected": {
+			Config: "credential_store: helper\ncredential_helper: osxkeychain",
+			Want:   &aiven.HelperStore{},
+		},
+		"helper from environment": {
+			Helper: "osxkeychain",
+			Want:   &aiven.HelperStore{},
+		},
+		"encrypted from environment": {
+			Passphrase: "passphrase",
+			Want:       &aiven.EncryptedFileStore{},
+		},
+		"selection over environment": {
+			Config: "credential_store: file",
+			Helper: "osxkeychain",
+			Want:   &aiven.FileStore{},
+		},
+	}
+
+	for label, tc := range testCases {
+		t.Run(label, func(t *testing.T) {
+			withConfig(t, tc.Config)
+			t.Setenv("AIVEN_CREDENTIAL_HELPER", tc.Helper)
+			t.Setenv("AIVEN_CREDENTIAL_PASSPHRASE", tc.Passphrase)
+
+			got, err := credentialStore()
+			assert.Nil(t, err)
+			assert.IsType(t, tc.Want, got)
+		})
+	}
+}
+
+func TestLoadSessionMigratesToken(t *testing.T) {
+	withConfig(t, "")
+
+	// sessions were saved with the token before the credential store
+	assert.Nil(t, os.MkdirAll(configDir(), 0700))
+	assert.Nil(t, ioutil.WriteFile(sessionFile(), []byte("email: me@example.com\ntoken: legacy-token\n"), 0600))
+
+	s, token, ok, err := loadSession()
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "me@example.com", s.Email)
+	assert.Equal(t, "legacy-token", token)
+
+	data, err := ioutil.ReadFile(sessionFile())
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), "legacy-token", "token scrubbed from session file")
+
+	token, ok, err = storedSecret(sessionKey)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "legacy-token", token)
+}
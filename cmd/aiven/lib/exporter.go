@@ -0,0 +1,206 @@
+package lib
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/savaki/aiven"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var Exporter = cli.Command{
+	Name:  "exporter",
+	Usage: "serve service metrics and kafka topic stats as a prometheus /metrics endpoint",
+	Flags: []cli.Flag{
+		flagEmail,
+		flagPassword,
+		flagOTP,
+		flagProject,
+		flagService,
+		flagListen,
+	},
+	Action: serveExporter,
+}
+
+var invalidMetricChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// metricName converts an aiven metric name into a valid prometheus metric name
+func metricName(name string) string {
+	return "aiven_service_" + invalidMetricChars.ReplaceAllString(name, "_")
+}
+
+// labels renders prometheus labels in the order provided as key, value pairs
+func labels(kv ...string) string {
+	var parts []string
+	for i := 0; i+1 < len(kv); i += 2 {
+		v := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(kv[i+1])
+		parts = append(parts, fmt.Sprintf(`%v="%v"`, kv[i], v))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// helpText escapes text for use in a prometheus # HELP line
+func helpText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(text)
+}
+
+// exporter collects metrics from aiven on each scrape
+type exporter struct {
+	client  *aiven.Client
+	project string
+	service string
+}
+
+func (e exporter) writeServiceMetrics(ctx context.Context, w io.Writer) error {
+	metrics, err := e.client.Services().Metrics(ctx, aiven.ServiceMetricsIn{
+		Project: e.project,
+		Service: e.service,
+		Period:  aiven.MetricsPeriodHour,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, metric := range metrics {
+		name := metricName(metric.Name)
+		fmt.Fprintf(w, "# HELP %v %v\n", name, helpText(metric.Title))
+		fmt.Fprintf(w, "# TYPE %v gauge\n", name)
+		for _, series := range metric.Series {
+			if point, ok := series.Latest(); ok {
+				fmt.Fprintf(w, "%v%v %v\n", name, labels("project", e.project, "service", e.service, "host", series.Label), point.Value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeTopicMetrics writes the stats of every topic that could be retrieved. Topics that
+// could not be are logged and reported by the returned error.
+func (e exporter) writeTopicMetrics(ctx context.Context, w io.Writer) error {
+	kafka := e.client.Kafka()
+	topics, err := kafka.ListTopics(ctx, aiven.KafkaListTopicsIn{
+		Project: e.project,
+		Service: e.service,
+	})
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	var sizes, offsets, lags bytes.Buffer
+	for _, topic := range topics {
+		info, err := kafka.TopicInfo(ctx, aiven.KafkaTopicInfoIn{
+			Project:   e.project,
+			Service:   e.service,
+			TopicName: topic.TopicName,
+		})
+		if err == nil && len(info.Errors) > 0 {
+			err = errors.Wrapf(info.Errors[0], "unable to retrieve topic info for topic, %v", topic.TopicName)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			failed++
+			continue
+		}
+
+		for _, p := range info.Topic.Partitions {
+			partition := fmt.Sprint(p.Partition)
+			fmt.Fprintf(&sizes, "aiven_kafka_partition_size_bytes%v %v\n", labels("project", e.project, "service", e.service, "topic", topic.TopicName, "partition", partition), p.Size)
+			fmt.Fprintf(&offsets, "aiven_kafka_partition_latest_offset%v %v\n", labels("project", e.project, "service", e.service, "topic", topic.TopicName, "partition", partition), p.LatestOffset)
+
+			lag := p.ConsumerLag()
+			groups := make([]string, 0, len(lag))
+			for group := range lag {
+				groups = append(groups, group)
+			}
+			sort.Strings(groups)
+			for _, group := range groups {
+				fmt.Fprintf(&lags, "aiven_kafka_consumer_group_lag%v %v\n", labels("project", e.project, "service", e.service, "topic", topic.TopicName, "partition", partition, "group", group), lag[group])
+			}
+		}
+	}
+
+	fmt.Fprintln(w, "# HELP aiven_kafka_partition_size_bytes size of the partition on disk")
+	fmt.Fprintln(w, "# TYPE aiven_kafka_partition_size_bytes gauge")
+	sizes.WriteTo(w)
+	fmt.Fprintln(w, "# HELP aiven_kafka_partition_latest_offset latest offset of the partition")
+	fmt.Fprintln(w, "# TYPE aiven_kafka_partition_latest_offset counter")
+	offsets.WriteTo(w)
+	fmt.Fprintln(w, "# HELP aiven_kafka_consumer_group_lag messages between the consumer group offset and the latest offset")
+	fmt.Fprintln(w, "# TYPE aiven_kafka_consumer_group_lag gauge")
+	lags.WriteTo(w)
+
+	if failed > 0 {
+		return errors.Errorf("unable to retrieve %v of %v topics for project:service, %v:%v", failed, len(topics), e.project, e.service)
+	}
+
+	return nil
+}
+
+// ServeHTTP writes whatever metrics could be collected. aiven_exporter_scrape_error is
+// set to 1 when any of them could not be, rather than failing the whole scrape.
+func (e exporter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), time.Second*30)
+	defer cancel()
+
+	scrapeError := 0
+	var buf bytes.Buffer
+	if err := e.writeServiceMetrics(ctx, &buf); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		scrapeError = 1
+	}
+
+	service, err := e.client.Services().Get(ctx, aiven.ServiceGetIn{
+		Project: e.project,
+		Service: e.service,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		scrapeError = 1
+	} else if service.ServiceType == "kafka" {
+		if err := e.writeTopicMetrics(ctx, &buf); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			scrapeError = 1
+		}
+	}
+
+	fmt.Fprintln(&buf, "# HELP aiven_exporter_scrape_error 1 if any metrics could not be retrieved from aiven during the scrape")
+	fmt.Fprintln(&buf, "# TYPE aiven_exporter_scrape_error gauge")
+	fmt.Fprintf(&buf, "aiven_exporter_scrape_error%v %v\n", labels("project", e.project, "service", e.service), scrapeError)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	buf.WriteTo(w)
+}
+
+func serveExporter(_ *cli.Context) error {
+	client, err := newClient()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter{
+		client:  client,
+		project: opts.Project,
+		service: opts.Service,
+	})
+
+	fmt.Fprintf(os.Stderr, "serving metrics for %v:%v on %v/metrics\n", opts.Project, opts.Service, opts.Listen)
+	if err := http.ListenAndServe(opts.Listen, mux); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package lib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricName(t *testing.T) {
+	assert.Equal(t, "aiven_service_cpu_usage", metricName("cpu_usage"))
+	assert.Equal(t, "aiven_service_disk_usage_", metricName("disk-usage%"))
+}
+
+func TestLabels(t *testing.T) {
+	assert.Equal(t, `{project="p",service="s"}`, labels("project", "p", "service", "s"))
+	assert.Equal(t, `{host="a\\b\"c\nd"}`, labels("host", "a\\b\"c\nd"))
+	assert.Equal(t, `{a="1"}`, labels("a", "1", "dangling"))
+}
+
+func TestExporter(t *testing.T) {
+	fakeRoutes(t, map[string]fakeResponse{
+		"/v1beta/project/project/service/service/metrics": {http.StatusOK, `{"metrics":{"cpu_usage":{
+			"data":{"cols":[{"label":"time","type":"date"},{"label":"node-1","type":"number"}],"rows":[["2026-10-19T10:00:00Z",12.5]]},
+			"hints":{"title":"CPU \\ usage\nper node"}}}}`},
+		"/v1beta/project/project/service/service":              {http.StatusOK, `{"service":{"service_type":"kafka","topics":[{"topic_name":"events"},{"topic_name":"broken"}]}}`},
+		"/v1beta/project/project/service/service/topic/events": {http.StatusOK, `{"topic":{"partitions":[{"partition":0,"size":100,"latest_offset":10,"consumer_groups":[{"group_name":"app","offset":7}]}]}}`},
+		"/v1beta/project/project/service/service/topic/broken": {http.StatusInternalServerError, `{"errors":[{"message":"boom","status":500}],"message":"boom"}`},
+	})
+
+	e := exporter{
+		client:  aiven.NewWithToken("token"),
+		project: "project",
+		service: "service",
+	}
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code, "metrics retrieved are served despite the failed topic")
+	assert.Equal(t, `# HELP aiven_service_cpu_usage CPU \\ usage\nper node
+# TYPE aiven_service_cpu_usage gauge
+aiven_service_cpu_usage{project="project",service="service",host="node-1"} 12.5
+# HELP aiven_kafka_partition_size_bytes size of the partition on disk
+# TYPE aiven_kafka_partition_size_bytes gauge
+aiven_kafka_partition_size_bytes{project="project",service="service",topic="events",partition="0"} 100
+# HELP aiven_kafka_partition_latest_offset latest offset of the partition
+# TYPE aiven_kafka_partition_latest_offset counter
+aiven_kafka_partition_latest_offset{project="project",service="service",topic="events",partition="0"} 10
+# HELP aiven_kafka_consumer_group_lag messages between the consumer group offset and the latest offset
+# TYPE aiven_kafka_consumer_group_lag gauge
+aiven_kafka_consumer_group_lag{project="project",service="service",topic="events",partition="0",group="app"} 3
+# HELP aiven_exporter_scrape_error 1 if any metrics could not be retrieved from aiven during the scrape
+# TYPE aiven_exporter_scrape_error gauge
+aiven_exporter_scrape_error{project="project",service="service"} 1
+`, w.Body.String())
+}
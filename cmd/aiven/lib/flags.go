@@ -2,11 +2,11 @@ package lib
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"time"
 
+	"github.com/savaki/aiven"
 	"github.com/savaki/aiven/kafka"
 	"gopkg.in/urfave/cli.v1"
 )
@@ -15,15 +15,129 @@ var opts = struct {
 	Email    string
 	Password string
 	OTP      string
-	Project  string
-	Service  string
-	Topic    struct {
+	Token    string
+	TokenArg string
+	Profile  string
+	Output   struct {
+		Format   string
+		Template string
+		JSONPath string
+	}
+	Project string
+	Service string
+	Topic   struct {
 		Name           string
 		CleanupPolicy  string
 		Partitions     int
 		Replication    int
 		RetentionHours int
 	}
+	Cloud     string
+	AccountID string
+	Username  string
+	Replica   string
+	Timeout   time.Duration
+	Interval  time.Duration
+	Sort      string
+	Near      string
+	Limit     int
+	DryRun    bool
+	Follow    bool
+	Grep      string
+	Period    string
+	Listen    string
+	Since     time.Duration
+	File      string
+	Fork      struct {
+		Name               string
+		BackupName         string
+		RecoveryTargetTime string
+	}
+	NewProject struct {
+		CopyFrom    string
+		CountryCode string
+		TechEmails  cli.StringSlice
+	}
+	ServiceConfig struct {
+		Type                  string
+		Plan                  string
+		UserConfig            string
+		MaintenanceDOW        string
+		MaintenanceTime       string
+		TerminationProtection string
+	}
+	Database struct {
+		Name      string
+		LCCollate string
+		LCCType   string
+	}
+	Pool struct {
+		Name string
+		Mode string
+		Size int
+	}
+	Query struct {
+		PID       int
+		Terminate bool
+	}
+	Index struct {
+		Name    string
+		Pattern string
+		Days    int
+	}
+	VPC struct {
+		ID          string
+		NetworkCIDR string
+		PeerAccount string
+		PeerVPC     string
+		PeerRegion  string
+		PeerCIDRs   cli.StringSlice
+	}
+	Integration struct {
+		ID               string
+		Type             string
+		Source           string
+		Dest             string
+		SourceEndpointID string
+		DestEndpointID   string
+		EndpointName     string
+		EndpointType     string
+	}
+	ACL struct {
+		Categories cli.StringSlice
+		Channels   cli.StringSlice
+		Commands   cli.StringSlice
+		Keys       cli.StringSlice
+	}
+	IPFilter struct {
+		Networks    cli.StringSlice
+		Description string
+		Force       bool
+	}
+	StaticIPID    string
+	ShowPasswords bool
+	Member        struct {
+		Email string
+		Role  string
+	}
+	Team struct {
+		ID     string
+		Name   string
+		UserID string
+		Type   string
+	}
+	AccessToken struct {
+		Description    string
+		MaxAge         time.Duration
+		ExtendWhenUsed bool
+		Prefix         string
+	}
+	Billing struct {
+		Invoice string
+		SaveAs  string
+		Month   string
+		Format  string
+	}
 }{}
 
 var (
@@ -45,6 +159,34 @@ var (
 		EnvVar:      "AIVEN_OTP",
 		Destination: &opts.OTP,
 	}
+	flagToken = cli.StringFlag{
+		Name:        "token",
+		Usage:       "use - to read the aiven access token from stdin, which is otherwise taken from $AIVEN_TOKEN; takes precedence over email and password",
+		Destination: &opts.TokenArg,
+	}
+	flagProfile = cli.StringFlag{
+		Name:        "profile",
+		Usage:       "named profile to take defaults from; defaults to the active profile",
+		EnvVar:      "AIVEN_PROFILE",
+		Destination: &opts.Profile,
+	}
+	flagOutput = cli.StringFlag{
+		Name:        "output, o",
+		Value:       OutputJSON,
+		Usage:       "output format; table, json, yaml, csv or template",
+		EnvVar:      "AIVEN_OUTPUT",
+		Destination: &opts.Output.Format,
+	}
+	flagTemplate = cli.StringFlag{
+		Name:        "template",
+		Usage:       "go template applied to the json output e.g. '{{range .}}{{.topic_name}} {{end}}'",
+		Destination: &opts.Output.Template,
+	}
+	flagJSONPath = cli.StringFlag{
+		Name:        "jsonpath",
+		Usage:       "print the fields selected by a jsonpath expression e.g. '{[*].topic_name}'",
+		Destination: &opts.Output.JSONPath,
+	}
 	flagProject = cli.StringFlag{
 		Name:        "project",
 		Usage:       "aiven project",
@@ -57,6 +199,92 @@ var (
 		EnvVar:      "AIVEN_SERVICE",
 		Destination: &opts.Service,
 	}
+	flagCloud = cli.StringFlag{
+		Name:        "cloud",
+		Usage:       "cloud name e.g. google-europe-west1",
+		EnvVar:      "AIVEN_CLOUD",
+		Destination: &opts.Cloud,
+	}
+	flagTimeout = cli.DurationFlag{
+		Name:        "timeout",
+		Value:       30 * time.Minute,
+		Usage:       "how long to wait before giving up",
+		Destination: &opts.Timeout,
+	}
+	flagReportTimeout = cli.DurationFlag{
+		Name:        "timeout",
+		Value:       5 * time.Minute,
+		Usage:       "how long to wait for every project to be reported",
+		Destination: &opts.Timeout,
+	}
+	flagPruneTimeout = cli.DurationFlag{
+		Name:        "timeout",
+		Value:       5 * time.Minute,
+		Usage:       "how long to wait for every index to be deleted",
+		Destination: &opts.Timeout,
+	}
+	flagInterval = cli.DurationFlag{
+		Name:        "interval",
+		Value:       10 * time.Second,
+		Usage:       "how often to poll aiven",
+		Destination: &opts.Interval,
+	}
+	flagSort = cli.StringFlag{
+		Name:        "sort",
+		Usage:       "field to sort results by",
+		Destination: &opts.Sort,
+	}
+	flagLimit = cli.IntFlag{
+		Name:        "limit",
+		Value:       20,
+		Usage:       "maximum number of results",
+		Destination: &opts.Limit,
+	}
+	flagDryRun = cli.BoolFlag{
+		Name:        "dry-run",
+		Usage:       "show what would change without changing it",
+		Destination: &opts.DryRun,
+	}
+	flagFile = cli.StringFlag{
+		Name:        "file",
+		Value:       "-",
+		Usage:       "file to read input from; - reads stdin",
+		Destination: &opts.File,
+	}
+	flagFollow = cli.BoolFlag{
+		Name:        "follow, f",
+		Usage:       "keep polling for new entries",
+		Destination: &opts.Follow,
+	}
+	flagGrep = cli.StringFlag{
+		Name:        "grep",
+		Usage:       "only print entries matching this regular expression",
+		Destination: &opts.Grep,
+	}
+	flagPeriod = cli.StringFlag{
+		Name:        "period",
+		Value:       aiven.MetricsPeriodHour,
+		Usage:       "metrics period; hour, day, week, month or year",
+		Destination: &opts.Period,
+	}
+	flagListen = cli.StringFlag{
+		Name:        "listen",
+		Value:       ":9273",
+		Usage:       "address to serve on",
+		EnvVar:      "AIVEN_EXPORTER_LISTEN",
+		Destination: &opts.Listen,
+	}
+	flagSince = cli.DurationFlag{
+		Name:        "since",
+		Value:       24 * time.Hour,
+		Usage:       "only include entries from this long ago e.g. 24h",
+		Destination: &opts.Since,
+	}
+	flagNear = cli.StringFlag{
+		Name:        "near",
+		Usage:       "lat,lon to sort results by distance from e.g. 52.37,4.90",
+		Destination: &opts.Near,
+	}
 	// kafka specific
 	//
 	flagName = cli.StringFlag{
@@ -93,23 +321,434 @@ var (
 		EnvVar:      "TOPIC_REPLICATION_HOURS",
 		Destination: &opts.Topic.RetentionHours,
 	}
+	// project specific
+	//
+	flagCopyFromProject = cli.StringFlag{
+		Name:        "copy-from",
+		Usage:       "existing project to copy billing and contact details from",
+		Destination: &opts.NewProject.CopyFrom,
+	}
+	flagCountryCode = cli.StringFlag{
+		Name:        "country-code",
+		Usage:       "two letter billing country code",
+		Destination: &opts.NewProject.CountryCode,
+	}
+	flagAccountID = cli.StringFlag{
+		Name:        "account-id",
+		Usage:       "aiven account id",
+		EnvVar:      "AIVEN_ACCOUNT_ID",
+		Destination: &opts.AccountID,
+	}
+	flagTechEmail = cli.StringSliceFlag{
+		Name:  "tech-email",
+		Usage: "technical contact email; may be repeated",
+		Value: &opts.NewProject.TechEmails,
+	}
+
+	// service specific
+	//
+	flagServiceType = cli.StringFlag{
+		Name:        "type",
+		Usage:       "service type e.g. kafka, pg, mysql, redis",
+		Destination: &opts.ServiceConfig.Type,
+	}
+	flagPlan = cli.StringFlag{
+		Name:        "plan",
+		Usage:       "service plan e.g. business-4",
+		Destination: &opts.ServiceConfig.Plan,
+	}
+	flagUserConfig = cli.StringFlag{
+		Name:        "user-config",
+		Usage:       "service user config as a json object",
+		Destination: &opts.ServiceConfig.UserConfig,
+	}
+	flagMaintenanceDOW = cli.StringFlag{
+		Name:        "maintenance-dow",
+		Usage:       "day of week for maintenance e.g. sunday",
+		Destination: &opts.ServiceConfig.MaintenanceDOW,
+	}
+	flagMaintenanceTime = cli.StringFlag{
+		Name:        "maintenance-time",
+		Usage:       "utc time of day for maintenance e.g. 02:00:00",
+		Destination: &opts.ServiceConfig.MaintenanceTime,
+	}
+	flagTerminationProtection = cli.StringFlag{
+		Name:        "termination-protection",
+		Usage:       "true or false; leave empty to keep the current setting",
+		Destination: &opts.ServiceConfig.TerminationProtection,
+	}
+
+	flagUsername = cli.StringFlag{
+		Name:        "username",
+		Usage:       "service user name",
+		Destination: &opts.Username,
+	}
+	// database specific
+	//
+	flagDatabase = cli.StringFlag{
+		Name:        "database",
+		Usage:       "name of database",
+		Destination: &opts.Database.Name,
+	}
+	flagLCCollate = cli.StringFlag{
+		Name:        "lc-collate",
+		Usage:       "database collation e.g. en_US.UTF-8",
+		Destination: &opts.Database.LCCollate,
+	}
+	flagLCCType = cli.StringFlag{
+		Name:        "lc-ctype",
+		Usage:       "database character classification e.g. en_US.UTF-8",
+		Destination: &opts.Database.LCCType,
+	}
+	// postgres specific
+	//
+	flagPool = cli.StringFlag{
+		Name:        "pool",
+		Usage:       "name of connection pool",
+		Destination: &opts.Pool.Name,
+	}
+	flagPoolMode = cli.StringFlag{
+		Name:        "pool-mode",
+		Usage:       "pool mode; session, transaction or statement",
+		Destination: &opts.Pool.Mode,
+	}
+	flagPoolSize = cli.IntFlag{
+		Name:        "pool-size",
+		Usage:       "number of server connections in the pool",
+		Destination: &opts.Pool.Size,
+	}
+	flagPID = cli.IntFlag{
+		Name:        "pid",
+		Usage:       "pid of backend running the query",
+		Destination: &opts.Query.PID,
+	}
+	flagTerminate = cli.BoolFlag{
+		Name:        "terminate",
+		Usage:       "terminate the backend rather than cancel the query",
+		Destination: &opts.Query.Terminate,
+	}
+	flagForkName = cli.StringFlag{
+		Name:        "name",
+		Usage:       "name of the new service",
+		Destination: &opts.Fork.Name,
+	}
+	flagBackupName = cli.StringFlag{
+		Name:        "backup-name",
+		Usage:       "backup to restore; defaults to the latest",
+		Destination: &opts.Fork.BackupName,
+	}
+	flagRecoveryTargetTime = cli.StringFlag{
+		Name:        "recovery-target-time",
+		Usage:       "point in time to restore to, RFC3339 e.g. 2026-10-01T12:00:00Z",
+		Destination: &opts.Fork.RecoveryTargetTime,
+	}
+	flagReplica = cli.StringFlag{
+		Name:        "replica",
+		Usage:       "name of read replica service",
+		Destination: &opts.Replica,
+	}
+	// opensearch specific
+	//
+	flagIndex = cli.StringFlag{
+		Name:        "index",
+		Usage:       "name of index",
+		Destination: &opts.Index.Name,
+	}
+	flagIndexPattern = cli.StringFlag{
+		Name:        "pattern",
+		Usage:       "glob matched against index names e.g. logs-*",
+		Destination: &opts.Index.Pattern,
+	}
+	flagIndexDays = cli.IntFlag{
+		Name:        "days",
+		Value:       30,
+		Usage:       "delete indexes created more than this many days ago",
+		Destination: &opts.Index.Days,
+	}
+	// vpc specific
+	//
+	flagVPCID = cli.StringFlag{
+		Name:        "vpc-id",
+		Usage:       "project vpc id",
+		EnvVar:      "AIVEN_VPC_ID",
+		Destination: &opts.VPC.ID,
+	}
+	flagNetworkCIDR = cli.StringFlag{
+		Name:        "network-cidr",
+		Usage:       "ip range of the vpc e.g. 10.0.0.0/24",
+		Destination: &opts.VPC.NetworkCIDR,
+	}
+	flagPeerAccount = cli.StringFlag{
+		Name:        "peer-account",
+		Usage:       "aws account id, gcp project or azure subscription to peer with",
+		Destination: &opts.VPC.PeerAccount,
+	}
+	flagPeerVPC = cli.StringFlag{
+		Name:        "peer-vpc",
+		Usage:       "vpc id or network name to peer with",
+		Destination: &opts.VPC.PeerVPC,
+	}
+	flagPeerRegion = cli.StringFlag{
+		Name:        "peer-region",
+		Usage:       "region of peer vpc, if it differs from the project vpc",
+		Destination: &opts.VPC.PeerRegion,
+	}
+	flagPeerCIDR = cli.StringSliceFlag{
+		Name:  "peer-cidr",
+		Usage: "ip range of the peer vpc to route; may be repeated",
+		Value: &opts.VPC.PeerCIDRs,
+	}
+	// integration specific
+	//
+	flagIntegrationID = cli.StringFlag{
+		Name:        "id",
+		Usage:       "integration or endpoint id",
+		Destination: &opts.Integration.ID,
+	}
+	flagIntegrationType = cli.StringFlag{
+		Name:        "integration-type",
+		Usage:       "integration type e.g. logs, metrics, datadog, kafka_connect",
+		Destination: &opts.Integration.Type,
+	}
+	flagIntegrationSource = cli.StringFlag{
+		Name:        "source",
+		Usage:       "source service",
+		Destination: &opts.Integration.Source,
+	}
+	flagIntegrationDest = cli.StringFlag{
+		Name:        "dest",
+		Usage:       "destination service",
+		Destination: &opts.Integration.Dest,
+	}
+	flagIntegrationSourceEndpoint = cli.StringFlag{
+		Name:        "source-endpoint",
+		Usage:       "source endpoint id",
+		Destination: &opts.Integration.SourceEndpointID,
+	}
+	flagIntegrationDestEndpoint = cli.StringFlag{
+		Name:        "dest-endpoint",
+		Usage:       "destination endpoint id",
+		Destination: &opts.Integration.DestEndpointID,
+	}
+	flagEndpointName = cli.StringFlag{
+		Name:        "endpoint-name",
+		Usage:       "name of integration endpoint",
+		Destination: &opts.Integration.EndpointName,
+	}
+	flagEndpointType = cli.StringFlag{
+		Name:        "endpoint-type",
+		Usage:       "endpoint type e.g. prometheus, datadog, rsyslog, external_kafka",
+		Destination: &opts.Integration.EndpointType,
+	}
+	// redis specific
+	//
+	flagACLCategories = cli.StringSliceFlag{
+		Name:  "category",
+		Usage: "acl category rule e.g. -@dangerous; may be repeated",
+		Value: &opts.ACL.Categories,
+	}
+	flagACLChannels = cli.StringSliceFlag{
+		Name:  "channel",
+		Usage: "pub/sub channel pattern e.g. events:*; may be repeated",
+		Value: &opts.ACL.Channels,
+	}
+	flagACLCommands = cli.StringSliceFlag{
+		Name:  "command",
+		Usage: "acl command rule e.g. +get; may be repeated",
+		Value: &opts.ACL.Commands,
+	}
+	flagACLKeys = cli.StringSliceFlag{
+		Name:  "key",
+		Usage: "key pattern e.g. app:*; may be repeated",
+		Value: &opts.ACL.Keys,
+	}
+	flagShowPasswords = cli.BoolFlag{
+		Name:        "show-passwords",
+		Usage:       "include user passwords in the output",
+		Destination: &opts.ShowPasswords,
+	}
+	// ip filter specific
+	//
+	flagNetwork = cli.StringSliceFlag{
+		Name:  "network",
+		Usage: "ip address or cidr e.g. 10.0.0.0/24; may be repeated",
+		Value: &opts.IPFilter.Networks,
+	}
+	flagNetworkDescription = cli.StringFlag{
+		Name:        "description",
+		Usage:       "description of the added networks",
+		Destination: &opts.IPFilter.Description,
+	}
+	flagIPFilterForce = cli.BoolFlag{
+		Name:        "force",
+		Usage:       "allow removing the last networks, which blocks all access to the service",
+		Destination: &opts.IPFilter.Force,
+	}
+	// static ip specific
+	//
+	flagStaticIPID = cli.StringFlag{
+		Name:        "id",
+		Usage:       "static ip address id",
+		Destination: &opts.StaticIPID,
+	}
+	// member specific
+	//
+	flagProjects = cli.StringFlag{
+		Name:        "project",
+		Usage:       "aiven project; comma separated to operate on several projects",
+		EnvVar:      "AIVEN_PROJECT",
+		Destination: &opts.Project,
+	}
+	flagMemberEmail = cli.StringFlag{
+		Name:        "member",
+		Usage:       "email of the project member",
+		Destination: &opts.Member.Email,
+	}
+	flagMemberRole = cli.StringFlag{
+		Name:        "role",
+		Usage:       "member role: admin, developer, operator or read_only",
+		Value:       string(aiven.MemberTypeDeveloper),
+		Destination: &opts.Member.Role,
+	}
+	flagMemberNewRole = cli.StringFlag{
+		Name:        "role",
+		Usage:       "new member role, required: admin, developer, operator or read_only",
+		Destination: &opts.Member.Role,
+	}
+	// team specific
+	//
+	flagTeamID = cli.StringFlag{
+		Name:        "team-id",
+		Usage:       "account team id",
+		EnvVar:      "AIVEN_TEAM_ID",
+		Destination: &opts.Team.ID,
+	}
+	flagTeamName = cli.StringFlag{
+		Name:        "team-name",
+		Usage:       "account team name",
+		Destination: &opts.Team.Name,
+	}
+	flagTeamUserID = cli.StringFlag{
+		Name:        "user-id",
+		Usage:       "user id of the team member",
+		Destination: &opts.Team.UserID,
+	}
+	flagTeamType = cli.StringFlag{
+		Name:        "team-type",
+		Usage:       "access of the team to the project: admin, developer, operator or read_only",
+		Value:       string(aiven.MemberTypeReadOnly),
+		Destination: &opts.Team.Type,
+	}
+	// token specific
+	//
+	flagTokenDescription = cli.StringFlag{
+		Name:        "description",
+		Usage:       "what the token is used for",
+		Destination: &opts.AccessToken.Description,
+	}
+	flagTokenMaxAge = cli.DurationFlag{
+		Name:        "max-age",
+		Usage:       "how long the token remains valid e.g. 720h; never expires if unset",
+		Destination: &opts.AccessToken.MaxAge,
+	}
+	flagTokenExtendWhenUsed = cli.BoolFlag{
+		Name:        "extend-when-used",
+		Usage:       "push back expiry by max-age each time the token is used",
+		Destination: &opts.AccessToken.ExtendWhenUsed,
+	}
+	flagTokenPrefix = cli.StringFlag{
+		Name:        "prefix",
+		Usage:       "prefix of the token to revoke, as shown by token list; required",
+		Destination: &opts.AccessToken.Prefix,
+	}
+	// billing specific
+	//
+	flagInvoice = cli.StringFlag{
+		Name:        "invoice",
+		Usage:       "invoice number",
+		Destination: &opts.Billing.Invoice,
+	}
+	flagSaveAs = cli.StringFlag{
+		Name:        "save-as",
+		Usage:       "file to save the invoice pdf to; defaults to <invoice>.pdf",
+		Destination: &opts.Billing.SaveAs,
+	}
+	flagMonth = cli.StringFlag{
+		Name:        "month",
+		Usage:       "billing month e.g. 2026-09; defaults to the current month",
+		Destination: &opts.Billing.Month,
+	}
+	flagReportFormat = cli.StringFlag{
+		Name:        "format, o",
+		Usage:       "report format e.g. json or csv; same as the global --output",
+		Destination: &opts.Billing.Format,
+	}
 )
 
-func Do(fn func(ctx context.Context) (interface{}, error)) cli.ActionFunc {
+// GlobalFlags apply to every command
+var GlobalFlags = []cli.Flag{
+	flagProfile,
+	flagToken,
+	flagOutput,
+	flagTemplate,
+	flagJSONPath,
+}
+
+// newClient returns an aiven client authenticated with the credentials from the command
+// line, falling back to those of the profile and then the session cached by login
+func newClient() (*aiven.Client, error) {
+	if err := applyProfile(); err != nil {
+		return nil, err
+	}
+
+	if opts.Token != "" {
+		return aiven.NewWithToken(opts.Token), nil
+	}
+	if opts.Password == "" {
+		client, ok, err := sessionClient()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return client, nil
+		}
+	}
+	return aiven.NewOTP(opts.Email, opts.Password, opts.OTP)
+}
+
+// partialError is returned along with the results of a command that succeeded for some
+// items and failed for others so that Do reports both
+type partialError struct {
+	error
+}
+
+// Do runs fn and renders its result in the format selected by the global output flags;
+// columns select the fields shown in table and csv output
+func Do(fn func(ctx context.Context) (interface{}, error), columns ...string) cli.ActionFunc {
 	return func(*cli.Context) error {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		timeout := opts.Timeout
+		if timeout == 0 {
+			timeout = time.Second * 10
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
 
 		out, err := fn(ctx)
 		if err != nil {
+			// report what did succeed before the failures
+			if _, ok := err.(partialError); ok && out != nil {
+				render(os.Stdout, out, columns)
+			}
 			fmt.Fprintln(os.Stderr, err)
 			os.Exit(1)
 		}
 
 		if out != nil {
-			encoder := json.NewEncoder(os.Stdout)
-			encoder.SetIndent("", "  ")
-			encoder.Encode(out)
+			if err := render(os.Stdout, out, columns); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
 		}
 
 		return nil
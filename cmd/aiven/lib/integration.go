@@ -0,0 +1,190 @@
+package lib
+
+import (
+	"context"
+
+	"github.com/savaki/aiven"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var Integration = cli.Command{
+	Name:  "integration",
+	Usage: "service integration related commands",
+	Subcommands: cli.Commands{
+		{
+			Name:  "list",
+			Usage: "list integrations of service",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+			},
+			Action: Do(listIntegrations, "service_integration_id", "integration_type", "source_service", "dest_service", "enabled", "active"),
+		},
+		{
+			Name:  "create",
+			Usage: "create integration between services or endpoints",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagIntegrationType,
+				flagIntegrationSource,
+				flagIntegrationDest,
+				flagIntegrationSourceEndpoint,
+				flagIntegrationDestEndpoint,
+				flagUserConfig,
+			},
+			Action: Do(createIntegration),
+		},
+		{
+			Name:  "delete",
+			Usage: "delete integration",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagIntegrationID,
+			},
+			Action: Do(deleteIntegration),
+		},
+		{
+			Name:  "endpoint",
+			Usage: "manage integration endpoints",
+			Subcommands: cli.Commands{
+				{
+					Name:  "list",
+					Usage: "list integration endpoints",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+					},
+					Action: Do(listEndpoints, "endpoint_id", "endpoint_name", "endpoint_type"),
+				},
+				{
+					Name:  "create",
+					Usage: "create integration endpoint",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagEndpointName,
+						flagEndpointType,
+						flagUserConfig,
+					},
+					Action: Do(createEndpoint),
+				},
+				{
+					Name:  "delete",
+					Usage: "delete integration endpoint",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagIntegrationID,
+					},
+					Action: Do(deleteEndpoint),
+				},
+			},
+		},
+	},
+}
+
+func listIntegrations(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Integrations().List(ctx, aiven.IntegrationListIn{
+		Project: opts.Project,
+		Service: opts.Service,
+	})
+}
+
+func createIntegration(ctx context.Context) (interface{}, error) {
+	config, err := userConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Integrations().Create(ctx, aiven.IntegrationCreateIn{
+		Project:          opts.Project,
+		DestEndpointID:   opts.Integration.DestEndpointID,
+		DestService:      opts.Integration.Dest,
+		IntegrationType:  aiven.IntegrationType(opts.Integration.Type),
+		SourceEndpointID: opts.Integration.SourceEndpointID,
+		SourceService:    opts.Integration.Source,
+		UserConfig:       config,
+	})
+}
+
+func deleteIntegration(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Integrations().Delete(ctx, aiven.IntegrationDeleteIn{
+		Project:       opts.Project,
+		IntegrationID: opts.Integration.ID,
+	})
+}
+
+func listEndpoints(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Integrations().ListEndpoints(ctx, aiven.IntegrationListEndpointsIn{
+		Project: opts.Project,
+	})
+}
+
+func createEndpoint(ctx context.Context) (interface{}, error) {
+	config, err := userConfig()
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Integrations().CreateEndpoint(ctx, aiven.IntegrationCreateEndpointIn{
+		Project:      opts.Project,
+		EndpointName: opts.Integration.EndpointName,
+		EndpointType: aiven.EndpointType(opts.Integration.EndpointType),
+		UserConfig:   config,
+	})
+}
+
+func deleteEndpoint(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Integrations().DeleteEndpoint(ctx, aiven.IntegrationDeleteEndpointIn{
+		Project:    opts.Project,
+		EndpointID: opts.Integration.ID,
+	})
+}
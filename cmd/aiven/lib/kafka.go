@@ -3,7 +3,6 @@ package lib
 import (
 	"context"
 
-	"github.com/savaki/aiven"
 	"github.com/savaki/aiven/kafka"
 	"gopkg.in/urfave/cli.v1"
 )
@@ -22,7 +21,7 @@ var Kafka = cli.Command{
 				flagProject,
 				flagService,
 			},
-			Action: Do(listTopics),
+			Action: Do(listTopics, "topic_name", "partitions", "replication", "retention_hours", "state"),
 		},
 		{
 			Name:  "create-topic",
@@ -58,7 +57,7 @@ var Kafka = cli.Command{
 }
 
 func listTopics(ctx context.Context) (interface{}, error) {
-	client, err := aiven.NewOTP(opts.Email, opts.Password, opts.OTP)
+	client, err := newClient()
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +69,7 @@ func listTopics(ctx context.Context) (interface{}, error) {
 }
 
 func createTopic(ctx context.Context) (interface{}, error) {
-	client, err := aiven.NewOTP(opts.Email, opts.Password, opts.OTP)
+	client, err := newClient()
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +86,7 @@ func createTopic(ctx context.Context) (interface{}, error) {
 }
 
 func deleteTopic(ctx context.Context) (interface{}, error) {
-	client, err := aiven.NewOTP(opts.Email, opts.Password, opts.OTP)
+	client, err := newClient()
 	if err != nil {
 		return nil, err
 	}
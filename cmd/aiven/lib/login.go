@@ -0,0 +1,267 @@
+package lib
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/savaki/aiven"
+	"golang.org/x/term"
+	"gopkg.in/urfave/cli.v1"
+	"gopkg.in/yaml.v2"
+)
+
+var Login = cli.Command{
+	Name:  "login",
+	Usage: "authenticate interactively and cache the session token",
+	Flags: []cli.Flag{
+		flagEmail,
+	},
+	Action: login,
+}
+
+var Logout = cli.Command{
+	Name:   "logout",
+	Usage:  "revoke and remove the cached session token",
+	Action: logout,
+}
+
+// session records who is logged in; the token itself is kept in the credential store
+type session struct {
+	Email string `yaml:"email"`
+
+	// Token is only present in sessions saved before tokens moved to the credential
+	// store; loadSession moves it there
+	Token string `yaml:"token,omitempty"`
+}
+
+func sessionFile() string {
+	return filepath.Join(configDir(), "session.yaml")
+}
+
+// loadSession returns the cached session and its token; ok is false when not logged in
+func loadSession() (s session, token string, ok bool, err error) {
+	data, err := ioutil.ReadFile(sessionFile())
+	if os.IsNotExist(err) {
+		return session{}, "", false, nil
+	}
+	if err != nil {
+		return session{}, "", false, errors.Wrapf(err, "unable to read session, %v", sessionFile())
+	}
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return session{}, "", false, errors.Wrapf(err, "unable to parse session, %v", sessionFile())
+	}
+	if s.Token != "" {
+		if err := saveSession(session{Email: s.Email}, s.Token); err != nil {
+			return session{}, "", false, errors.Wrapf(err, "unable to move session token to the credential store")
+		}
+		s.Token = ""
+	}
+
+	token, ok, err = storedSecret(sessionKey)
+	if err != nil {
+		return session{}, "", false, err
+	}
+
+	return s, token, ok, nil
+}
+
+func saveSession(s session, token string) error {
+	store, err := credentialStore()
+	if err != nil {
+		return err
+	}
+	if err := store.Set(sessionKey, token); err != nil {
+		return err
+	}
+
+	filename := sessionFile()
+	if err := os.MkdirAll(filepath.Dir(filename), 0700); err != nil {
+		return errors.Wrapf(err, "unable to create config dir, %v", filepath.Dir(filename))
+	}
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return errors.Wrapf(err, "unable to encode session")
+	}
+	if err := ioutil.WriteFile(filename, data, 0600); err != nil {
+		return errors.Wrapf(err, "unable to write session, %v", filename)
+	}
+
+	return nil
+}
+
+func removeSession() error {
+	store, err := credentialStore()
+	if err != nil {
+		return err
+	}
+	if err := store.Delete(sessionKey); err != nil {
+		return err
+	}
+
+	if err := os.Remove(sessionFile()); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "unable to remove session, %v", sessionFile())
+	}
+	return nil
+}
+
+// sessionClient returns a client using the cached session, after checking with aiven
+// that the token is still valid. ok is false when there is no usable session.
+func sessionClient() (client *aiven.Client, ok bool, err error) {
+	s, token, ok, err := loadSession()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	if opts.Email != "" && opts.Email != s.Email {
+		return nil, false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client = aiven.NewWithToken(token)
+	if _, err := client.Me(ctx); err != nil {
+		// keep the session when aiven could not be asked, e.g. a timeout
+		if !aiven.IsUnauthorized(err) {
+			return nil, false, err
+		}
+		removeSession()
+		return nil, false, errors.Wrapf(err, "cached session for %v is no longer valid; run aiven login", s.Email)
+	}
+
+	return client, true, nil
+}
+
+// stdin is shared by every prompt so that input piped to the cli, e.g. a passphrase
+// followed by a token, is not lost in the buffer of an earlier reader
+var stdin *bufio.Reader
+
+// stdinReader returns the reader shared by the prompts
+func stdinReader() *bufio.Reader {
+	if stdin == nil {
+		stdin = bufio.NewReader(os.Stdin)
+	}
+	return stdin
+}
+
+// prompt reads a line from stdin after printing label to stderr
+func prompt(r *bufio.Reader, label string) (string, error) {
+	fmt.Fprint(os.Stderr, label)
+	line, err := r.ReadString('\n')
+	if err != nil && line == "" {
+		return "", errors.Wrapf(err, "unable to read %v", strings.TrimSuffix(label, ": "))
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// promptHidden reads a line from stdin without echoing it when stdin is a terminal
+func promptHidden(r *bufio.Reader, label string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return prompt(r, label)
+	}
+
+	fmt.Fprint(os.Stderr, label)
+	data, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to read %v", strings.TrimSuffix(label, ": "))
+	}
+	return string(data), nil
+}
+
+func login(_ *cli.Context) error {
+	if err := interactiveLogin(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	return nil
+}
+
+// interactiveLogin prompts for credentials and caches the resulting session token
+func interactiveLogin() error {
+	if err := applyProfile(); err != nil {
+		return err
+	}
+
+	r := stdinReader()
+
+	email := opts.Email
+	if email == "" {
+		v, err := prompt(r, "Email: ")
+		if err != nil {
+			return err
+		}
+		email = v
+	}
+
+	password, err := promptHidden(r, "Password: ")
+	if err != nil {
+		return err
+	}
+
+	otp, err := prompt(r, "One time password (empty if not enabled): ")
+	if err != nil {
+		return err
+	}
+
+	client, err := aiven.NewOTP(email, password, otp)
+	if err != nil {
+		return err
+	}
+
+	if err := saveSession(session{Email: email}, client.Token()); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "logged in as %v\n", email)
+	return nil
+}
+
+// revokeSession invalidates the session token with aiven. An expired or already revoked
+// token has nothing left to revoke; any other failure means the token may still be valid.
+func revokeSession(ctx context.Context, token string) error {
+	client := aiven.NewWithToken(token)
+	if _, err := client.Me(ctx); err != nil {
+		if aiven.IsUnauthorized(err) {
+			return nil
+		}
+		return err
+	}
+	return client.Logout(ctx)
+}
+
+func logout(_ *cli.Context) error {
+	s, token, ok, err := loadSession()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if !ok {
+		fmt.Fprintln(os.Stderr, "not logged in")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := revokeSession(ctx, token); err != nil {
+		fmt.Fprintln(os.Stderr, errors.Wrapf(err, "session kept"))
+		os.Exit(1)
+	}
+
+	if err := removeSession(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Fprintf(os.Stderr, "logged out %v\n", s.Email)
+	return nil
+}
@@ -0,0 +1,170 @@
+package lib
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+// fakeAPI answers the requests of clients using the default transport, e.g. those
+// returned by aiven.NewWithToken, with status and body for the duration of the test
+func fakeAPI(t *testing.T, status int, body string) *[]string {
+	var paths []string
+	original := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path)
+		w := httptest.NewRecorder()
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+		return w.Result(), nil
+	})
+	t.Cleanup(func() {
+		http.DefaultTransport = original
+	})
+	return &paths
+}
+
+// fakeResponse is the answer of fakeRoutes to the requests for a path
+type fakeResponse struct {
+	Status int
+	Body   string
+}
+
+// fakeRoutes answers the requests of clients using the default transport with the
+// response for the request path, or a 404 for unknown paths, for the duration of the test
+func fakeRoutes(t *testing.T, routes map[string]fakeResponse) {
+	original := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		resp, ok := routes[req.URL.Path]
+		if !ok {
+			resp = fakeResponse{Status: http.StatusNotFound, Body: `{"errors":[{"message":"not found","status":404}],"message":"not found"}`}
+		}
+		w := httptest.NewRecorder()
+		w.WriteHeader(resp.Status)
+		w.Write([]byte(resp.Body))
+		return w.Result(), nil
+	})
+	t.Cleanup(func() {
+		http.DefaultTransport = original
+	})
+}
+
+func TestSession(t *testing.T) {
+	withConfig(t, "")
+
+	_, _, ok, err := loadSession()
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	err = saveSession(session{Email: "me@example.com"}, "secret-token")
+	assert.Nil(t, err)
+
+	s, token, ok, err := loadSession()
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "me@example.com", s.Email)
+	assert.Equal(t, "secret-token", token)
+
+	// both the session and the token it refers to are only readable by the current user
+	for _, filename := range []string{sessionFile(), filepath.Join(configDir(), "credentials.json")} {
+		info, err := os.Stat(filename)
+		assert.Nil(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), filename)
+	}
+
+	// the token is not kept in the session file itself
+	data, err := ioutil.ReadFile(sessionFile())
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), "secret-token")
+
+	assert.Nil(t, removeSession())
+	_, _, ok, err = loadSession()
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestSessionClient(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		withConfig(t, "")
+		assert.Nil(t, saveSession(session{Email: "me@example.com"}, "token"))
+		fakeAPI(t, http.StatusOK, `{"user":{"user":"me@example.com"}}`)
+
+		client, ok, err := sessionClient()
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "token", client.Token())
+	})
+
+	t.Run("email mismatch", func(t *testing.T) {
+		withConfig(t, "")
+		assert.Nil(t, saveSession(session{Email: "me@example.com"}, "token"))
+		paths := fakeAPI(t, http.StatusOK, `{"user":{"user":"me@example.com"}}`)
+
+		opts.Email = "other@example.com"
+		client, ok, err := sessionClient()
+		assert.Nil(t, err)
+		assert.False(t, ok)
+		assert.Nil(t, client)
+		assert.Len(t, *paths, 0, "session of another user is not used")
+
+		_, _, ok, _ = loadSession()
+		assert.True(t, ok, "session of another user is kept")
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		withConfig(t, "")
+		assert.Nil(t, saveSession(session{Email: "me@example.com"}, "token"))
+		fakeAPI(t, http.StatusUnauthorized, `{"errors":[{"message":"Invalid token","status":401}],"message":"Invalid token"}`)
+
+		_, ok, err := sessionClient()
+		assert.NotNil(t, err)
+		assert.False(t, ok)
+
+		_, _, ok, _ = loadSession()
+		assert.False(t, ok, "rejected session is removed")
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		withConfig(t, "")
+		assert.Nil(t, saveSession(session{Email: "me@example.com"}, "token"))
+		fakeAPI(t, http.StatusServiceUnavailable, `<html>unavailable</html>`)
+
+		_, ok, err := sessionClient()
+		assert.NotNil(t, err)
+		assert.False(t, ok)
+
+		_, _, ok, _ = loadSession()
+		assert.True(t, ok, "session is kept when aiven could not be asked")
+	})
+}
+
+func TestRevokeSession(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		paths := fakeAPI(t, http.StatusOK, `{}`)
+		assert.Nil(t, revokeSession(context.Background(), "token"))
+		assert.Equal(t, []string{"/v1beta/me", "/v1beta/me/logout"}, *paths)
+	})
+
+	t.Run("already invalid", func(t *testing.T) {
+		paths := fakeAPI(t, http.StatusUnauthorized, `{"errors":[{"message":"Invalid token","status":401}],"message":"Invalid token"}`)
+		assert.Nil(t, revokeSession(context.Background(), "token"))
+		assert.Equal(t, []string{"/v1beta/me"}, *paths)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		fakeAPI(t, http.StatusInternalServerError, `{"errors":[{"message":"Internal error","status":500}],"message":"Internal error"}`)
+		assert.NotNil(t, revokeSession(context.Background(), "token"))
+	})
+}
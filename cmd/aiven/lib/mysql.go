@@ -0,0 +1,97 @@
+package lib
+
+import (
+	"context"
+
+	"github.com/savaki/aiven"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var MySQL = cli.Command{
+	Name:  "mysql",
+	Usage: "mysql related commands",
+	Subcommands: cli.Commands{
+		{
+			Name:  "db",
+			Usage: "manage databases",
+			Subcommands: cli.Commands{
+				{
+					Name:  "list",
+					Usage: "list databases",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+					},
+					Action: Do(listMySQLDatabases),
+				},
+				{
+					Name:  "create",
+					Usage: "create database",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagDatabase,
+					},
+					Action: Do(createMySQLDatabase),
+				},
+				{
+					Name:  "delete",
+					Usage: "delete database",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagDatabase,
+					},
+					Action: Do(deleteMySQLDatabase),
+				},
+			},
+		},
+	},
+}
+
+func listMySQLDatabases(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.MySQL().ListDatabases(ctx, aiven.MySQLListDatabasesIn{
+		Project: opts.Project,
+		Service: opts.Service,
+	})
+}
+
+func createMySQLDatabase(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.MySQL().CreateDatabase(ctx, aiven.MySQLCreateDatabaseIn{
+		Project:  opts.Project,
+		Service:  opts.Service,
+		Database: opts.Database.Name,
+	})
+}
+
+func deleteMySQLDatabase(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.MySQL().DeleteDatabase(ctx, aiven.MySQLDeleteDatabaseIn{
+		Project:  opts.Project,
+		Service:  opts.Service,
+		Database: opts.Database.Name,
+	})
+}
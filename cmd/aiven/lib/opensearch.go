@@ -0,0 +1,190 @@
+package lib
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/savaki/aiven"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var OpenSearch = cli.Command{
+	Name:  "opensearch",
+	Usage: "opensearch related commands",
+	Subcommands: cli.Commands{
+		{
+			Name:  "index",
+			Usage: "manage indexes",
+			Subcommands: cli.Commands{
+				{
+					Name:  "list",
+					Usage: "list indexes",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+					},
+					Action: Do(listIndexes, "index_name", "health", "docs", "size", "create_time"),
+				},
+				{
+					Name:  "delete",
+					Usage: "delete index",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagIndex,
+					},
+					Action: Do(deleteIndex),
+				},
+				{
+					Name:  "prune",
+					Usage: "delete indexes matching --pattern older than --days",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagIndexPattern,
+						flagIndexDays,
+						flagDryRun,
+						flagPruneTimeout,
+					},
+					Action: Do(pruneIndexes),
+				},
+			},
+		},
+		{
+			Name:  "acl",
+			Usage: "manage access control",
+			Subcommands: cli.Commands{
+				{
+					Name:  "get",
+					Usage: "print acl config",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+					},
+					Action: Do(getOpenSearchACL),
+				},
+				{
+					Name:  "set",
+					Usage: "replace acl config with the json read from --file",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagFile,
+					},
+					Action: Do(setOpenSearchACL),
+				},
+			},
+		},
+	},
+}
+
+// readFile returns the contents of --file, or stdin when --file is -
+func readFile() ([]byte, error) {
+	if opts.File == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
+	return ioutil.ReadFile(opts.File)
+}
+
+func listIndexes(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.OpenSearch().ListIndexes(ctx, aiven.OpenSearchListIndexesIn{
+		Project: opts.Project,
+		Service: opts.Service,
+	})
+}
+
+func deleteIndex(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.OpenSearch().DeleteIndex(ctx, aiven.OpenSearchDeleteIndexIn{
+		Project: opts.Project,
+		Service: opts.Service,
+		Index:   opts.Index.Name,
+	})
+}
+
+func pruneIndexes(ctx context.Context) (interface{}, error) {
+	if opts.Index.Pattern == "" {
+		return nil, errors.New("--pattern is required")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := client.OpenSearch().PruneIndexes(ctx, aiven.OpenSearchPruneIndexesIn{
+		Project:   opts.Project,
+		Service:   opts.Service,
+		Pattern:   opts.Index.Pattern,
+		OlderThan: time.Duration(opts.Index.Days) * 24 * time.Hour,
+		DryRun:    opts.DryRun,
+	})
+	if err != nil && len(deleted) > 0 {
+		return deleted, partialError{err}
+	}
+
+	return deleted, err
+}
+
+func getOpenSearchACL(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.OpenSearch().GetACL(ctx, aiven.OpenSearchGetACLIn{
+		Project: opts.Project,
+		Service: opts.Service,
+	})
+}
+
+func setOpenSearchACL(ctx context.Context) (interface{}, error) {
+	data, err := readFile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read acl config")
+	}
+
+	var config aiven.OpenSearchACLConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse acl config")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.OpenSearch().SetACL(ctx, aiven.OpenSearchSetACLIn{
+		Project: opts.Project,
+		Service: opts.Service,
+		Config:  config,
+	})
+}
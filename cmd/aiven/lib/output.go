@@ -0,0 +1,392 @@
+package lib
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"gopkg.in/urfave/cli.v1"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	OutputJSON     = "json"
+	OutputYAML     = "yaml"
+	OutputTable    = "table"
+	OutputCSV      = "csv"
+	OutputTemplate = "template"
+)
+
+// generic converts v into the maps, slices and scalars of its json representation so
+// every output format, template and jsonpath sees the same field names as json
+func generic(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to encode output")
+	}
+
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to encode output")
+	}
+	return out, nil
+}
+
+// render writes v to w in the format selected by --output, --template or --jsonpath.
+// columns select the fields shown by the table and csv formats; all scalar fields are
+// shown when no columns are given.
+func render(w io.Writer, v interface{}, columns []string) error {
+	if opts.Output.JSONPath != "" {
+		return renderJSONPath(w, v, opts.Output.JSONPath)
+	}
+	if opts.Output.Template != "" {
+		return renderTemplate(w, v, opts.Output.Template)
+	}
+
+	switch opts.Output.Format {
+	case "", OutputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+
+	case OutputYAML:
+		content, err := generic(v)
+		if err != nil {
+			return err
+		}
+		data, err := yaml.Marshal(content)
+		if err != nil {
+			return errors.Wrapf(err, "unable to encode output as yaml")
+		}
+		_, err = w.Write(data)
+		return err
+
+	case OutputTable, OutputCSV:
+		content, err := generic(v)
+		if err != nil {
+			return err
+		}
+		header, rows := tabulate(content, columns)
+		if opts.Output.Format == OutputCSV {
+			return renderCSV(w, header, rows)
+		}
+		return renderTable(w, header, rows)
+
+	case OutputTemplate:
+		return errors.New("--output template requires --template")
+
+	default:
+		return errors.Errorf("unsupported output, %v; expected table, json, yaml, csv or template", opts.Output.Format)
+	}
+}
+
+// tabulate flattens content into rows of cells; a single object becomes a single row
+func tabulate(content interface{}, columns []string) ([]string, [][]string) {
+	var items []interface{}
+	switch v := content.(type) {
+	case []interface{}:
+		items = v
+	case nil:
+	default:
+		items = []interface{}{v}
+	}
+
+	if len(columns) == 0 {
+		columns = scalarKeys(items)
+	}
+	if len(columns) == 0 {
+		// not objects e.g. a list of strings
+		var rows [][]string
+		for _, item := range items {
+			rows = append(rows, []string{cell(item)})
+		}
+		return []string{"value"}, rows
+	}
+
+	var rows [][]string
+	for _, item := range items {
+		m, _ := item.(map[string]interface{})
+		row := make([]string, 0, len(columns))
+		for _, column := range columns {
+			row = append(row, cell(m[column]))
+		}
+		rows = append(rows, row)
+	}
+	return columns, rows
+}
+
+// scalarKeys returns the sorted keys of the first object that hold scalar values
+func scalarKeys(items []interface{}) []string {
+	if len(items) == 0 {
+		return nil
+	}
+	m, ok := items[0].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var keys []string
+	for key, value := range m {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// cell formats a single value for table and csv output
+func cell(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		data, _ := json.Marshal(value)
+		return string(data)
+	}
+}
+
+// renderTable writes rows aligned in columns below the header; a nil header is omitted
+func renderTable(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if header != nil {
+		titles := make([]string, 0, len(header))
+		for _, column := range header {
+			titles = append(titles, strings.ToUpper(strings.Replace(column, "_", " ", -1)))
+		}
+		fmt.Fprintln(tw, strings.Join(titles, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// renderCSV writes the header, unless nil, followed by rows as csv
+func renderCSV(w io.Writer, header []string, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if header != nil {
+		cw.Write(header)
+	}
+	cw.WriteAll(rows)
+	return cw.Error()
+}
+
+// outputSelected returns true when any of --output, --template or --jsonpath was given
+func outputSelected(c *cli.Context) bool {
+	return c.GlobalIsSet("output") || opts.Output.Template != "" || opts.Output.JSONPath != ""
+}
+
+// stream renders the items of a command that produces them over time, e.g. while
+// following logs, in the format selected by the global output flags. json is written as
+// one line per item, yaml as one document per item, and the table and csv header is
+// written once. When no output flag is given, text formats each item as a line instead.
+type stream struct {
+	w       io.Writer
+	columns []string
+	text    func(v interface{}) string
+	started bool
+}
+
+func newStream(c *cli.Context, w io.Writer, text func(v interface{}) string, columns ...string) *stream {
+	if outputSelected(c) {
+		text = nil
+	}
+	return &stream{
+		w:       w,
+		columns: columns,
+		text:    text,
+	}
+}
+
+// emit renders a single item
+func (s *stream) emit(v interface{}) error {
+	started := s.started
+	s.started = true
+
+	switch {
+	case s.text != nil:
+		_, err := fmt.Fprintln(s.w, s.text(v))
+		return err
+	case opts.Output.JSONPath != "" || opts.Output.Template != "":
+		return render(s.w, v, s.columns)
+	}
+
+	switch opts.Output.Format {
+	case "", OutputJSON:
+		return json.NewEncoder(s.w).Encode(v)
+
+	case OutputYAML:
+		fmt.Fprintln(s.w, "---")
+		return render(s.w, v, s.columns)
+
+	case OutputTable, OutputCSV:
+		content, err := generic(v)
+		if err != nil {
+			return err
+		}
+		header, rows := tabulate(content, s.columns)
+		if started {
+			header = nil
+		}
+		if opts.Output.Format == OutputCSV {
+			return renderCSV(s.w, header, rows)
+		}
+		return renderTable(s.w, header, rows)
+
+	default:
+		return render(s.w, v, s.columns)
+	}
+}
+
+// renderTemplate executes a go template against the json representation of v e.g.
+// {{range .}}{{.topic_name}}{{"\n"}}{{end}}
+func renderTemplate(w io.Writer, v interface{}, text string) error {
+	t, err := template.New("output").Parse(text)
+	if err != nil {
+		return errors.Wrapf(err, "invalid template")
+	}
+
+	content, err := generic(v)
+	if err != nil {
+		return err
+	}
+	if err := t.Execute(w, content); err != nil {
+		return errors.Wrapf(err, "unable to execute template")
+	}
+	return nil
+}
+
+// renderJSONPath prints the values selected by a jsonpath expression, one per line.
+// Supported syntax is the common subset: {.items[*].name}, $.a.b[0] or .a['b'].
+func renderJSONPath(w io.Writer, v interface{}, expr string) error {
+	content, err := generic(v)
+	if err != nil {
+		return err
+	}
+
+	values, err := jsonPath(content, expr)
+	if err != nil {
+		return err
+	}
+	for _, value := range values {
+		fmt.Fprintln(w, cell(value))
+	}
+	return nil
+}
+
+func jsonPath(content interface{}, expr string) ([]interface{}, error) {
+	path := strings.TrimSpace(expr)
+	path = strings.TrimPrefix(path, "{")
+	path = strings.TrimSuffix(path, "}")
+	path = strings.TrimPrefix(path, "$")
+
+	values := []interface{}{content}
+	for path != "" {
+		var key string
+		switch {
+		case strings.HasPrefix(path, "."):
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end == -1 {
+				end = len(path)
+			}
+			key, path = path[:end], path[end:]
+			if key == "" {
+				continue
+			}
+			values = selectKey(values, key)
+
+		case strings.HasPrefix(path, "["):
+			end := strings.Index(path, "]")
+			if end == -1 {
+				return nil, errors.Errorf("invalid jsonpath, %v: missing ]", expr)
+			}
+			key, path = path[1:end], path[end+1:]
+
+			if key == "*" || key == "" {
+				values = selectAll(values)
+				continue
+			}
+			if quoted := strings.Trim(key, `'"`); quoted != key {
+				values = selectKey(values, quoted)
+				continue
+			}
+			index, err := strconv.Atoi(key)
+			if err != nil {
+				return nil, errors.Errorf("invalid jsonpath, %v: unsupported index, %v", expr, key)
+			}
+			values = selectIndex(values, index)
+
+		default:
+			return nil, errors.Errorf("invalid jsonpath, %v: expected . or [ at %v", expr, path)
+		}
+	}
+
+	return values, nil
+}
+
+func selectKey(values []interface{}, key string) []interface{} {
+	var out []interface{}
+	for _, value := range values {
+		if m, ok := value.(map[string]interface{}); ok {
+			if v, ok := m[key]; ok {
+				out = append(out, v)
+			}
+		}
+	}
+	return out
+}
+
+func selectAll(values []interface{}) []interface{} {
+	var out []interface{}
+	for _, value := range values {
+		switch v := value.(type) {
+		case []interface{}:
+			out = append(out, v...)
+		case map[string]interface{}:
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				out = append(out, v[key])
+			}
+		}
+	}
+	return out
+}
+
+func selectIndex(values []interface{}, index int) []interface{} {
+	var out []interface{}
+	for _, value := range values {
+		items, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		i := index
+		if i < 0 {
+			i += len(items)
+		}
+		if i >= 0 && i < len(items) {
+			out = append(out, items[i])
+		}
+	}
+	return out
+}
@@ -0,0 +1,219 @@
+package lib
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// decode returns the generic json representation of data
+func decode(t *testing.T, data string) interface{} {
+	var v interface{}
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	return v
+}
+
+func TestJSONPath(t *testing.T) {
+	content := decode(t, `{
+  "name": "kafka-1",
+  "tags": {"env": "prod", "team.name": "data"},
+  "topics": [
+    {"topic_name": "a", "partitions": 1},
+    {"topic_name": "b", "partitions": 3},
+    {"topic_name": "c", "partitions": 6}
+  ]
+}`)
+
+	testCases := map[string]struct {
+		Expr string
+		Want []interface{}
+	}{
+		"field": {
+			Expr: ".name",
+			Want: []interface{}{"kafka-1"},
+		},
+		"braces and dollar": {
+			Expr: "{$.name}",
+			Want: []interface{}{"kafka-1"},
+		},
+		"nested": {
+			Expr: ".tags.env",
+			Want: []interface{}{"prod"},
+		},
+		"index": {
+			Expr: ".topics[1].topic_name",
+			Want: []interface{}{"b"},
+		},
+		"negative index": {
+			Expr: ".topics[-1].topic_name",
+			Want: []interface{}{"c"},
+		},
+		"index out of range": {
+			Expr: ".topics[3].topic_name",
+		},
+		"wildcard": {
+			Expr: "{.topics[*].topic_name}",
+			Want: []interface{}{"a", "b", "c"},
+		},
+		"empty brackets": {
+			Expr: ".topics[].partitions",
+			Want: []interface{}{float64(1), float64(3), float64(6)},
+		},
+		"wildcard object sorted by key": {
+			Expr: ".tags[*]",
+			Want: []interface{}{"prod", "data"},
+		},
+		"single quoted key": {
+			Expr: ".tags['team.name']",
+			Want: []interface{}{"data"},
+		},
+		"double quoted key": {
+			Expr: `$["name"]`,
+			Want: []interface{}{"kafka-1"},
+		},
+		"missing key": {
+			Expr: ".missing.name",
+		},
+		"root": {
+			Expr: "$",
+			Want: []interface{}{content},
+		},
+	}
+
+	for label, tc := range testCases {
+		t.Run(label, func(t *testing.T) {
+			got, err := jsonPath(content, tc.Expr)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.Want, got)
+		})
+	}
+}
+
+func TestJSONPathInvalid(t *testing.T) {
+	content := decode(t, `{"topics": [{"topic_name": "a"}]}`)
+
+	testCases := map[string]string{
+		"missing bracket":   ".topics[0",
+		"unsupported index": ".topics[0:1]",
+		"filter":            ".topics[?(@.partitions > 1)]",
+		"no separator":      "topics",
+		"junk after index":  ".topics[0]topic_name",
+	}
+
+	for label, expr := range testCases {
+		t.Run(label, func(t *testing.T) {
+			_, err := jsonPath(content, expr)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestTabulate(t *testing.T) {
+	testCases := map[string]struct {
+		Content string
+		Columns []string
+		Header  []string
+		Rows    [][]string
+	}{
+		"single object": {
+			Content: `{"name": "a", "partitions": 3, "config": {"x": 1}, "tags": ["t"]}`,
+			Header:  []string{"name", "partitions"},
+			Rows:    [][]string{{"a", "3"}},
+		},
+		"list of objects": {
+			Content: `[{"name": "a", "ok": true}, {"name": "b", "ok": false}]`,
+			Header:  []string{"name", "ok"},
+			Rows:    [][]string{{"a", "true"}, {"b", "false"}},
+		},
+		"list of scalars": {
+			Content: `["a", 1.5, null]`,
+			Header:  []string{"value"},
+			Rows:    [][]string{{"a"}, {"1.5"}, {""}},
+		},
+		"missing columns": {
+			Content: `[{"name": "a", "partitions": 3}, {"name": "b"}]`,
+			Columns: []string{"name", "partitions", "retention_hours"},
+			Header:  []string{"name", "partitions", "retention_hours"},
+			Rows:    [][]string{{"a", "3", ""}, {"b", "", ""}},
+		},
+		"nested values as json": {
+			Content: `{"name": "a", "tags": ["x", "y"]}`,
+			Columns: []string{"name", "tags"},
+			Header:  []string{"name", "tags"},
+			Rows:    [][]string{{"a", `["x","y"]`}},
+		},
+		"null": {
+			Content: `null`,
+			Header:  []string{"value"},
+		},
+	}
+
+	for label, tc := range testCases {
+		t.Run(label, func(t *testing.T) {
+			header, rows := tabulate(decode(t, tc.Content), tc.Columns)
+			assert.Equal(t, tc.Header, header)
+			assert.Equal(t, tc.Rows, rows)
+		})
+	}
+}
+
+func TestRenderTable(t *testing.T) {
+	var buf bytes.Buffer
+	err := renderTable(&buf, []string{"topic_name", "partitions"}, [][]string{{"a", "1"}, {"longer", "12"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "TOPIC NAME  PARTITIONS\na           1\nlonger      12\n", buf.String())
+}
+
+func TestStream(t *testing.T) {
+	type entry struct {
+		Msg  string `json:"msg"`
+		Unit string `json:"unit"`
+	}
+	entries := []entry{{Msg: "a", Unit: "x"}, {Msg: "b", Unit: "z"}}
+
+	original := opts.Output
+	defer func() { opts.Output = original }()
+
+	testCases := map[string]struct {
+		Format string
+		Text   func(v interface{}) string
+		Want   string
+	}{
+		"text": {
+			Format: OutputJSON,
+			Text: func(v interface{}) string {
+				return v.(entry).Unit + ": " + v.(entry).Msg
+			},
+			Want: "x: a\nz: b\n",
+		},
+		"json lines": {
+			Format: OutputJSON,
+			Want:   "{\"msg\":\"a\",\"unit\":\"x\"}\n{\"msg\":\"b\",\"unit\":\"z\"}\n",
+		},
+		"csv header once": {
+			Format: OutputCSV,
+			Want:   "unit,msg\nx,a\nz,b\n",
+		},
+		"yaml documents": {
+			Format: OutputYAML,
+			Want:   "---\nmsg: a\nunit: x\n---\nmsg: b\nunit: z\n",
+		},
+	}
+
+	for label, tc := range testCases {
+		t.Run(label, func(t *testing.T) {
+			opts.Output.Format = tc.Format
+
+			var buf bytes.Buffer
+			s := &stream{w: &buf, columns: []string{"unit", "msg"}, text: tc.Text}
+			for _, e := range entries {
+				assert.Nil(t, s.emit(e))
+			}
+			assert.Equal(t, tc.Want, buf.String())
+		})
+	}
+}
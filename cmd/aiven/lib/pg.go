@@ -0,0 +1,418 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/savaki/aiven"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var Postgres = cli.Command{
+	Name:  "pg",
+	Usage: "postgres related commands",
+	Subcommands: cli.Commands{
+		{
+			Name:  "db",
+			Usage: "manage logical databases",
+			Subcommands: cli.Commands{
+				{
+					Name:  "list",
+					Usage: "list databases",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+					},
+					Action: Do(listPgDatabases),
+				},
+				{
+					Name:  "create",
+					Usage: "create database",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagDatabase,
+						flagLCCollate,
+						flagLCCType,
+					},
+					Action: Do(createPgDatabase),
+				},
+				{
+					Name:  "delete",
+					Usage: "delete database",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagDatabase,
+					},
+					Action: Do(deletePgDatabase),
+				},
+			},
+		},
+		{
+			Name:  "pool",
+			Usage: "manage pgbouncer connection pools",
+			Subcommands: cli.Commands{
+				{
+					Name:  "list",
+					Usage: "list connection pools",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+					},
+					Action: Do(listPgPools, "pool_name", "database", "username", "pool_mode", "pool_size"),
+				},
+				{
+					Name:  "create",
+					Usage: "create connection pool",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagPool,
+						flagPoolMode,
+						flagPoolSize,
+						flagDatabase,
+						flagUsername,
+					},
+					Action: Do(createPgPool),
+				},
+				{
+					Name:  "update",
+					Usage: "update connection pool",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagPool,
+						flagPoolMode,
+						flagPoolSize,
+						flagDatabase,
+						flagUsername,
+					},
+					Action: Do(updatePgPool),
+				},
+				{
+					Name:  "delete",
+					Usage: "delete connection pool",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagPool,
+					},
+					Action: Do(deletePgPool),
+				},
+			},
+		},
+		{
+			Name:  "top",
+			Usage: "show pg_stat_statements query statistics, refreshed every --interval",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+				flagSort,
+				flagLimit,
+				flagInterval,
+			},
+			Action: pgTop,
+		},
+		{
+			Name:  "queries",
+			Usage: "list currently running queries",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+				flagSort,
+				flagLimit,
+			},
+			Action: Do(listPgQueries),
+		},
+		{
+			Name:  "cancel",
+			Usage: "cancel the query running on a backend",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+				flagPID,
+				flagTerminate,
+			},
+			Action: Do(cancelPgQuery),
+		},
+		{
+			Name:  "replica",
+			Usage: "manage read replicas",
+			Subcommands: cli.Commands{
+				{
+					Name:  "create",
+					Usage: "create read replica of service",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagReplica,
+						flagPlan,
+						flagCloud,
+					},
+					Action: Do(createPgReplica),
+				},
+			},
+		},
+	},
+}
+
+func listPgDatabases(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Postgres().ListDatabases(ctx, aiven.PostgresListDatabasesIn{
+		Project: opts.Project,
+		Service: opts.Service,
+	})
+}
+
+func createPgDatabase(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Postgres().CreateDatabase(ctx, aiven.PostgresCreateDatabaseIn{
+		Project:   opts.Project,
+		Service:   opts.Service,
+		Database:  opts.Database.Name,
+		LCCollate: opts.Database.LCCollate,
+		LCCType:   opts.Database.LCCType,
+	})
+}
+
+func deletePgDatabase(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Postgres().DeleteDatabase(ctx, aiven.PostgresDeleteDatabaseIn{
+		Project:  opts.Project,
+		Service:  opts.Service,
+		Database: opts.Database.Name,
+	})
+}
+
+func listPgPools(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Postgres().ListPools(ctx, aiven.PostgresListPoolsIn{
+		Project: opts.Project,
+		Service: opts.Service,
+	})
+}
+
+func createPgPool(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Postgres().CreatePool(ctx, aiven.PostgresCreatePoolIn{
+		Project:  opts.Project,
+		Service:  opts.Service,
+		Database: opts.Database.Name,
+		PoolMode: opts.Pool.Mode,
+		PoolName: opts.Pool.Name,
+		PoolSize: opts.Pool.Size,
+		Username: opts.Username,
+	})
+}
+
+func updatePgPool(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Postgres().UpdatePool(ctx, aiven.PostgresUpdatePoolIn{
+		Project:  opts.Project,
+		Service:  opts.Service,
+		PoolName: opts.Pool.Name,
+		Database: opts.Database.Name,
+		PoolMode: opts.Pool.Mode,
+		PoolSize: opts.Pool.Size,
+		Username: opts.Username,
+	})
+}
+
+func deletePgPool(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Postgres().DeletePool(ctx, aiven.PostgresDeletePoolIn{
+		Project:  opts.Project,
+		Service:  opts.Service,
+		PoolName: opts.Pool.Name,
+	})
+}
+
+func createPgReplica(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Postgres().CreateReplica(ctx, aiven.PostgresCreateReplicaIn{
+		Project:     opts.Project,
+		Service:     opts.Service,
+		ReplicaName: opts.Replica,
+		Plan:        opts.ServiceConfig.Plan,
+		Cloud:       opts.Cloud,
+	})
+}
+
+// orderBy returns --sort as an aiven order_by expression, defaulting to descending order
+func orderBy(defaultColumn string) string {
+	v := opts.Sort
+	if v == "" {
+		v = defaultColumn
+	}
+	if !strings.Contains(v, ":") {
+		v += ":desc"
+	}
+	return v
+}
+
+// oneLine collapses whitespace within the query and truncates it to n characters
+func oneLine(query string, n int) string {
+	v := []rune(strings.Join(strings.Fields(query), " "))
+	if len(v) > n {
+		return string(v[:n-3]) + "..."
+	}
+	return string(v)
+}
+
+// pgTopColumns are the query statistics shown by pg top in table and csv output
+var pgTopColumns = []string{"calls", "total_time", "mean_time", "rows", "user_name", "database_name", "query"}
+
+func pgTop(c *cli.Context) error {
+	if opts.Interval < time.Second {
+		fmt.Fprintln(os.Stderr, "--interval must be at least 1s")
+		os.Exit(1)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	// a refreshing table suits a terminal best unless another output was asked for
+	if !outputSelected(c) {
+		opts.Output.Format = OutputTable
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	for {
+		child, cancelChild := context.WithTimeout(ctx, time.Second*10)
+		stats, err := client.Postgres().QueryStats(child, aiven.PostgresQueryStatsIn{
+			Project: opts.Project,
+			Service: opts.Service,
+			Limit:   opts.Limit,
+			OrderBy: orderBy("total_time"),
+		})
+		cancelChild()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		if opts.Output.Format == OutputTable && opts.Output.Template == "" && opts.Output.JSONPath == "" {
+			for i := range stats {
+				stats[i].Query = oneLine(stats[i].Query, 80)
+			}
+			fmt.Print("\033[H\033[2J")
+			fmt.Printf("%v:%v - %v - sorted by %v\n\n", opts.Project, opts.Service, time.Now().Format(time.RFC3339), orderBy("total_time"))
+		}
+		if err := render(os.Stdout, stats, pgTopColumns); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
+func listPgQueries(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Postgres().CurrentQueries(ctx, aiven.PostgresCurrentQueriesIn{
+		Project: opts.Project,
+		Service: opts.Service,
+		Limit:   opts.Limit,
+		OrderBy: orderBy("query_duration"),
+	})
+}
+
+func cancelPgQuery(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Postgres().CancelQuery(ctx, aiven.PostgresCancelQueryIn{
+		Project:   opts.Project,
+		Service:   opts.Service,
+		PID:       opts.Query.PID,
+		Terminate: opts.Query.Terminate,
+	})
+}
@@ -0,0 +1,44 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOneLine(t *testing.T) {
+	testCases := map[string]struct {
+		Query string
+		N     int
+		Want  string
+	}{
+		"short": {
+			Query: "select 1",
+			N:     10,
+			Want:  "select 1",
+		},
+		"whitespace": {
+			Query: "select *\n  from t\twhere id = $1",
+			N:     80,
+			Want:  "select * from t where id = $1",
+		},
+		"truncated": {
+			Query: "select * from accounts",
+			N:     10,
+			Want:  "select ...",
+		},
+		"multi-byte": {
+			Query: "select 'ééééééééé'",
+			N:     12,
+			Want:  "select 'é...",
+		},
+	}
+
+	for label, tc := range testCases {
+		t.Run(label, func(t *testing.T) {
+			got := oneLine(tc.Query, tc.N)
+			assert.Equal(t, tc.Want, got)
+			assert.True(t, len([]rune(got)) <= tc.N)
+		})
+	}
+}
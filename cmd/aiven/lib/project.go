@@ -0,0 +1,415 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/savaki/aiven"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var Project = cli.Command{
+	Name:  "project",
+	Usage: "project related commands",
+	Subcommands: cli.Commands{
+		{
+			Name:  "list",
+			Usage: "list projects",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+			},
+			Action: Do(listProjects, "project_name", "default_cloud", "account_id", "estimated_balance"),
+		},
+		{
+			Name:  "get",
+			Usage: "describe project",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+			},
+			Action: Do(getProject),
+		},
+		{
+			Name:  "create",
+			Usage: "create project",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagCloud,
+				flagAccountID,
+				flagCopyFromProject,
+				flagCountryCode,
+				flagTechEmail,
+			},
+			Action: Do(createProject),
+		},
+		{
+			Name:  "delete",
+			Usage: "delete project",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+			},
+			Action: Do(deleteProject),
+		},
+		{
+			Name:  "events",
+			Usage: "print project event log",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagSince,
+				flagFollow,
+				flagInterval,
+			},
+			Action: projectEvents,
+		},
+		{
+			Name:  "member",
+			Usage: "manage project members and invitations",
+			Subcommands: cli.Commands{
+				{
+					Name:  "list",
+					Usage: "list members and pending invitations",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProjects,
+					},
+					Action: Do(listMembers),
+				},
+				{
+					Name:  "invite",
+					Usage: "invite user with role",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProjects,
+						flagMemberEmail,
+						flagMemberRole,
+					},
+					Action: Do(inviteMember),
+				},
+				{
+					Name:  "set-role",
+					Usage: "change role of member",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProjects,
+						flagMemberEmail,
+						flagMemberNewRole,
+					},
+					Action: Do(setMemberRole),
+				},
+				{
+					Name:  "remove",
+					Usage: "remove member",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProjects,
+						flagMemberEmail,
+					},
+					Action: Do(removeMember),
+				},
+				{
+					Name:  "cancel-invite",
+					Usage: "cancel pending invitation",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProjects,
+						flagMemberEmail,
+					},
+					Action: Do(cancelInvite),
+				},
+			},
+		},
+	},
+}
+
+func listProjects(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Projects().List(ctx)
+}
+
+func getProject(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Projects().Get(ctx, aiven.ProjectGetIn{
+		Project: opts.Project,
+	})
+}
+
+func createProject(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var techEmails []aiven.ContactEmail
+	for _, email := range opts.NewProject.TechEmails {
+		techEmails = append(techEmails, aiven.ContactEmail{Email: email})
+	}
+
+	return client.Projects().Create(ctx, aiven.ProjectCreateIn{
+		AccountID:       opts.AccountID,
+		Cloud:           opts.Cloud,
+		CopyFromProject: opts.NewProject.CopyFrom,
+		CountryCode:     opts.NewProject.CountryCode,
+		Project:         opts.Project,
+		TechEmails:      techEmails,
+	})
+}
+
+func deleteProject(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Projects().Delete(ctx, aiven.ProjectDeleteIn{
+		Project: opts.Project,
+	})
+}
+
+// projectResult reports the outcome of a member command for a single project
+type projectResult struct {
+	Project string `json:"project"`
+	Status  string `json:"status"`
+}
+
+// projects splits the comma separated --project flag
+func projects() ([]string, error) {
+	if err := applyProfile(); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, name := range strings.Split(opts.Project, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, errors.New("--project is required")
+	}
+	return names, nil
+}
+
+// forEachProject applies fn to every project named by --project. All projects are
+// attempted; the results of the projects that succeeded are returned along with an
+// error listing every project that failed.
+func forEachProject(ctx context.Context, fn func(ctx context.Context, client *aiven.Client, project string) (interface{}, error)) (interface{}, error) {
+	names, err := projects()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []interface{}
+	var failures []string
+	for _, project := range names {
+		result, err := fn(ctx, client, project)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		results = append(results, result)
+	}
+
+	if len(failures) > 0 {
+		err := errors.Errorf("%v of %v projects failed:\n%v", len(failures), len(names), strings.Join(failures, "\n"))
+		if len(results) == 0 {
+			return nil, err
+		}
+		return results, partialError{err}
+	}
+
+	return results, nil
+}
+
+// eachProject applies the member change fn to every project named by --project,
+// reporting the status of each as forEachProject does
+func eachProject(ctx context.Context, fn func(ctx context.Context, client *aiven.Client, project string) error) (interface{}, error) {
+	if opts.Member.Email == "" {
+		return nil, errors.New("--member is required")
+	}
+
+	return forEachProject(ctx, func(ctx context.Context, client *aiven.Client, project string) (interface{}, error) {
+		if err := fn(ctx, client, project); err != nil {
+			return nil, err
+		}
+		return projectResult{Project: project, Status: "ok"}, nil
+	})
+}
+
+// memberType returns --role, which must be one of the aiven member types
+func memberType() (aiven.MemberType, error) {
+	switch v := aiven.MemberType(opts.Member.Role); v {
+	case aiven.MemberTypeAdmin, aiven.MemberTypeDeveloper, aiven.MemberTypeOperator, aiven.MemberTypeReadOnly:
+		return v, nil
+	case "":
+		return "", errors.New("--role is required")
+	default:
+		return "", errors.Errorf("invalid role, %v; expected admin, developer, operator or read_only", opts.Member.Role)
+	}
+}
+
+func listMembers(ctx context.Context) (interface{}, error) {
+	type projectMembers struct {
+		Project string `json:"project"`
+		aiven.MemberListOut
+	}
+
+	return forEachProject(ctx, func(ctx context.Context, client *aiven.Client, project string) (interface{}, error) {
+		out, err := client.Members().List(ctx, aiven.MemberListIn{
+			Project: project,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return projectMembers{
+			Project:       project,
+			MemberListOut: out,
+		}, nil
+	})
+}
+
+func inviteMember(ctx context.Context) (interface{}, error) {
+	role, err := memberType()
+	if err != nil {
+		return nil, err
+	}
+
+	return eachProject(ctx, func(ctx context.Context, client *aiven.Client, project string) error {
+		return client.Members().Invite(ctx, aiven.MemberInviteIn{
+			Project:    project,
+			Email:      opts.Member.Email,
+			MemberType: role,
+		})
+	})
+}
+
+func setMemberRole(ctx context.Context) (interface{}, error) {
+	role, err := memberType()
+	if err != nil {
+		return nil, err
+	}
+
+	return eachProject(ctx, func(ctx context.Context, client *aiven.Client, project string) error {
+		return client.Members().SetRole(ctx, aiven.MemberSetRoleIn{
+			Project:    project,
+			Email:      opts.Member.Email,
+			MemberType: role,
+		})
+	})
+}
+
+func removeMember(ctx context.Context) (interface{}, error) {
+	return eachProject(ctx, func(ctx context.Context, client *aiven.Client, project string) error {
+		return client.Members().Remove(ctx, aiven.MemberRemoveIn{
+			Project: project,
+			Email:   opts.Member.Email,
+		})
+	})
+}
+
+func cancelInvite(ctx context.Context) (interface{}, error) {
+	return eachProject(ctx, func(ctx context.Context, client *aiven.Client, project string) error {
+		return client.Members().CancelInvite(ctx, aiven.MemberCancelInviteIn{
+			Project: project,
+			Email:   opts.Member.Email,
+		})
+	})
+}
+
+func projectEvents(c *cli.Context) error {
+	out := newStream(c, os.Stdout, func(v interface{}) string {
+		event := v.(aiven.ProjectEvent)
+		service := event.ServiceName
+		if service == "" {
+			service = "-"
+		}
+		return fmt.Sprintf("%v %v %v %v: %v", event.Time.Format(time.RFC3339), event.Actor, event.EventType, service, event.EventDesc)
+	}, "time", "actor", "event_type", "service_name", "event_desc")
+	emit := func(event aiven.ProjectEvent) {
+		if err := out.emit(event); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	client, err := newClient()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	since := time.Now().Add(-opts.Since)
+	if opts.Follow {
+		err = client.Projects().FollowEventLog(ctx, aiven.ProjectFollowEventLogIn{
+			Project:  opts.Project,
+			Since:    since,
+			Interval: opts.Interval,
+			Callback: emit,
+			OnError: func(err error) {
+				fmt.Fprintln(os.Stderr, err)
+			},
+		})
+		if err != nil && ctx.Err() == nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return nil
+	}
+
+	events, err := client.Projects().EventLog(ctx, aiven.ProjectEventLogIn{
+		Project: opts.Project,
+		Since:   since,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for _, event := range events {
+		emit(event)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package lib
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberType(t *testing.T) {
+	testCases := map[string]struct {
+		Role string
+		Want aiven.MemberType
+		Err  bool
+	}{
+		"admin":     {Role: "admin", Want: aiven.MemberTypeAdmin},
+		"read only": {Role: "read_only", Want: aiven.MemberTypeReadOnly},
+		"missing":   {Err: true},
+		"unknown":   {Role: "owner", Err: true},
+	}
+
+	for label, tc := range testCases {
+		t.Run(label, func(t *testing.T) {
+			opts.Member.Role = tc.Role
+			defer func() { opts.Member.Role = "" }()
+
+			got, err := memberType()
+			if tc.Err {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.Want, got)
+		})
+	}
+}
+
+func TestForEachProject(t *testing.T) {
+	fn := func(ctx context.Context, client *aiven.Client, project string) (interface{}, error) {
+		if project == "broken" {
+			return nil, errors.New("unable to reach broken")
+		}
+		return project, nil
+	}
+
+	t.Run("partial", func(t *testing.T) {
+		withConfig(t, "")
+		opts.Token = "token"
+		opts.Project = "a, broken,b"
+
+		results, err := forEachProject(context.Background(), fn)
+		assert.Equal(t, []interface{}{"a", "b"}, results)
+		assert.IsType(t, partialError{}, err)
+		assert.Contains(t, err.Error(), "1 of 3 projects failed")
+	})
+
+	t.Run("all failed", func(t *testing.T) {
+		withConfig(t, "")
+		opts.Token = "token"
+		opts.Project = "broken"
+
+		results, err := forEachProject(context.Background(), fn)
+		assert.Nil(t, results)
+		assert.NotNil(t, err)
+		_, partial := err.(partialError)
+		assert.False(t, partial)
+	})
+}
@@ -0,0 +1,158 @@
+package lib
+
+import (
+	"context"
+
+	"github.com/savaki/aiven"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var Redis = cli.Command{
+	Name:  "redis",
+	Usage: "redis and valkey related commands",
+	Subcommands: cli.Commands{
+		{
+			Name:  "user",
+			Usage: "manage acl users",
+			Subcommands: cli.Commands{
+				{
+					Name:  "list",
+					Usage: "list users",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagShowPasswords,
+					},
+					Action: Do(listRedisUsers),
+				},
+				{
+					Name:  "create",
+					Usage: "create user",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagUsername,
+						flagACLCategories,
+						flagACLChannels,
+						flagACLCommands,
+						flagACLKeys,
+					},
+					Action: Do(createRedisUser),
+				},
+				{
+					Name:  "update",
+					Usage: "replace the acl of a user",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagUsername,
+						flagACLCategories,
+						flagACLChannels,
+						flagACLCommands,
+						flagACLKeys,
+					},
+					Action: Do(updateRedisUser),
+				},
+				{
+					Name:  "delete",
+					Usage: "delete user",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagUsername,
+					},
+					Action: Do(deleteRedisUser),
+				},
+			},
+		},
+	},
+}
+
+// accessControl returns the acl described by the command line
+func accessControl() aiven.ServiceUserAccessControl {
+	return aiven.ServiceUserAccessControl{
+		RedisACLCategories: opts.ACL.Categories,
+		RedisACLChannels:   opts.ACL.Channels,
+		RedisACLCommands:   opts.ACL.Commands,
+		RedisACLKeys:       opts.ACL.Keys,
+	}
+}
+
+func listRedisUsers(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := client.Redis().ListUsers(ctx, aiven.RedisListUsersIn{
+		Project: opts.Project,
+		Service: opts.Service,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !opts.ShowPasswords {
+		for i := range users {
+			users[i].Password = ""
+		}
+	}
+
+	return users, nil
+}
+
+func createRedisUser(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	in := aiven.RedisCreateUserIn{
+		Project:  opts.Project,
+		Service:  opts.Service,
+		Username: opts.Username,
+	}
+	// without any rules the user gets the service's default acl
+	if acl := accessControl(); len(acl.RedisACLCategories)+len(acl.RedisACLChannels)+len(acl.RedisACLCommands)+len(acl.RedisACLKeys) > 0 {
+		in.AccessControl = &acl
+	}
+	return client.Redis().CreateUser(ctx, in)
+}
+
+func updateRedisUser(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Redis().UpdateUser(ctx, aiven.RedisUpdateUserIn{
+		Project:       opts.Project,
+		Service:       opts.Service,
+		Username:      opts.Username,
+		AccessControl: accessControl(),
+	})
+}
+
+func deleteRedisUser(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Redis().DeleteUser(ctx, aiven.RedisDeleteUserIn{
+		Project:  opts.Project,
+		Service:  opts.Service,
+		Username: opts.Username,
+	})
+}
@@ -0,0 +1,43 @@
+package lib
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListRedisUsers(t *testing.T) {
+	body := `{"service":{"users":[{"username":"default","password":"secret","type":"primary"}]}}`
+
+	testCases := map[string]struct {
+		ShowPasswords bool
+		WantPassword  string
+	}{
+		"hidden": {},
+		"shown": {
+			ShowPasswords: true,
+			WantPassword:  "secret",
+		},
+	}
+
+	for label, tc := range testCases {
+		t.Run(label, func(t *testing.T) {
+			withConfig(t, "")
+			fakeAPI(t, http.StatusOK, body)
+			opts.Token = "token"
+			opts.ShowPasswords = tc.ShowPasswords
+			defer func() { opts.ShowPasswords = false }()
+
+			out, err := listRedisUsers(context.Background())
+			assert.Nil(t, err)
+
+			users := out.([]aiven.ServiceUser)
+			assert.Len(t, users, 1)
+			assert.Equal(t, "default", users[0].Username)
+			assert.Equal(t, tc.WantPassword, users[0].Password)
+		})
+	}
+}
@@ -0,0 +1,780 @@
+package lib
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/savaki/aiven"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var Service = cli.Command{
+	Name:  "service",
+	Usage: "service related commands",
+	Subcommands: cli.Commands{
+		{
+			Name:  "list",
+			Usage: "list services in project",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+			},
+			Action: Do(listServices, "service_name", "service_type", "plan", "cloud_name", "state"),
+		},
+		{
+			Name:  "get",
+			Usage: "describe service",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+			},
+			Action: Do(getService),
+		},
+		{
+			Name:  "create",
+			Usage: "create service",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+				flagServiceType,
+				flagPlan,
+				flagCloud,
+				flagUserConfig,
+				flagMaintenanceDOW,
+				flagMaintenanceTime,
+				flagTerminationProtection,
+			},
+			Action: Do(createService),
+		},
+		{
+			Name:  "update",
+			Usage: "update service plan, maintenance window, termination protection or user config",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+				flagPlan,
+				flagCloud,
+				flagUserConfig,
+				flagMaintenanceDOW,
+				flagMaintenanceTime,
+				flagTerminationProtection,
+			},
+			Action: Do(updateService),
+		},
+		{
+			Name:  "power-on",
+			Usage: "power on service",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+			},
+			Action: Do(powerService(true)),
+		},
+		{
+			Name:  "power-off",
+			Usage: "power off service",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+			},
+			Action: Do(powerService(false)),
+		},
+		{
+			Name:  "wait",
+			Usage: "wait for service to reach the RUNNING state",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+				flagTimeout,
+				flagInterval,
+			},
+			Action: Do(waitService),
+		},
+		{
+			Name:  "plans",
+			Usage: "list plans for a service type; prices are shown when --cloud is set",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagServiceType,
+				flagCloud,
+				flagSort,
+			},
+			Action: Do(listPlans),
+		},
+		{
+			Name:  "logs",
+			Usage: "print recent service logs",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+				flagLimit,
+				flagFollow,
+				flagGrep,
+				flagInterval,
+			},
+			Action: serviceLogs,
+		},
+		{
+			Name:  "alerts",
+			Usage: "list active service alerts",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+			},
+			Action: Do(serviceAlerts, "create_time", "severity", "node_name", "event"),
+		},
+		{
+			Name:  "metrics",
+			Usage: "print service metrics",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+				flagPeriod,
+			},
+			Action: Do(serviceMetrics),
+		},
+		{
+			Name:  "backups",
+			Usage: "list service backups",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+			},
+			Action: Do(listBackups, "backup_name", "backup_time", "data_size"),
+		},
+		{
+			Name:  "fork",
+			Usage: "create a new service from a backup of --service",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+				flagForkName,
+				flagBackupName,
+				flagRecoveryTargetTime,
+				flagPlan,
+				flagCloud,
+			},
+			Action: Do(forkService),
+		},
+		{
+			Name:  "maintenance",
+			Usage: "manage service maintenance window and updates",
+			Subcommands: cli.Commands{
+				{
+					Name:  "show",
+					Usage: "show maintenance window and pending updates",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+					},
+					Action: Do(showMaintenance),
+				},
+				{
+					Name:  "set",
+					Usage: "set maintenance window",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagMaintenanceDOW,
+						flagMaintenanceTime,
+					},
+					Action: Do(setMaintenance),
+				},
+				{
+					Name:  "start",
+					Usage: "apply pending updates now",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+					},
+					Action: Do(startMaintenance),
+				},
+			},
+		},
+		{
+			Name:  "ip-filter",
+			Usage: "manage networks allowed to connect to the service",
+			Subcommands: cli.Commands{
+				{
+					Name:  "list",
+					Usage: "list allowed networks",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+					},
+					Action: Do(listIPFilter, "network", "description"),
+				},
+				{
+					Name:  "add",
+					Usage: "allow networks to connect",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagNetwork,
+						flagNetworkDescription,
+					},
+					Action: Do(addIPFilter),
+				},
+				{
+					Name:  "remove",
+					Usage: "stop allowing networks to connect",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagService,
+						flagNetwork,
+						flagIPFilterForce,
+					},
+					Action: Do(removeIPFilter),
+				},
+			},
+		},
+		{
+			Name:  "delete",
+			Usage: "delete service",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+			},
+			Action: Do(deleteService),
+		},
+	},
+}
+
+// userConfig decodes the --user-config flag, if provided
+func userConfig() (map[string]interface{}, error) {
+	if opts.ServiceConfig.UserConfig == "" {
+		return nil, nil
+	}
+
+	var v map[string]interface{}
+	if err := json.Unmarshal([]byte(opts.ServiceConfig.UserConfig), &v); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse user config")
+	}
+	return v, nil
+}
+
+// maintenance returns the maintenance window from the command line, if provided
+func maintenance() (*aiven.ServiceMaintenance, error) {
+	if opts.ServiceConfig.MaintenanceDOW == "" && opts.ServiceConfig.MaintenanceTime == "" {
+		return nil, nil
+	}
+
+	v, err := aiven.NewServiceMaintenance(opts.ServiceConfig.MaintenanceDOW, opts.ServiceConfig.MaintenanceTime)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// terminationProtection returns the termination protection setting from the command line, if provided
+func terminationProtection() (*bool, error) {
+	if opts.ServiceConfig.TerminationProtection == "" {
+		return nil, nil
+	}
+
+	v, err := strconv.ParseBool(opts.ServiceConfig.TerminationProtection)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid termination protection, %v", opts.ServiceConfig.TerminationProtection)
+	}
+	return &v, nil
+}
+
+func listServices(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Services().List(ctx, aiven.ServiceListIn{
+		Project: opts.Project,
+	})
+}
+
+func getService(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Services().Get(ctx, aiven.ServiceGetIn{
+		Project: opts.Project,
+		Service: opts.Service,
+	})
+}
+
+func createService(ctx context.Context) (interface{}, error) {
+	config, err := userConfig()
+	if err != nil {
+		return nil, err
+	}
+	protect, err := terminationProtection()
+	if err != nil {
+		return nil, err
+	}
+	window, err := maintenance()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Services().Create(ctx, aiven.ServiceCreateIn{
+		Project:               opts.Project,
+		Cloud:                 opts.Cloud,
+		Maintenance:           window,
+		Plan:                  opts.ServiceConfig.Plan,
+		ServiceName:           opts.Service,
+		ServiceType:           opts.ServiceConfig.Type,
+		TerminationProtection: protect != nil && *protect,
+		UserConfig:            config,
+	})
+}
+
+func updateService(ctx context.Context) (interface{}, error) {
+	config, err := userConfig()
+	if err != nil {
+		return nil, err
+	}
+	protect, err := terminationProtection()
+	if err != nil {
+		return nil, err
+	}
+	window, err := maintenance()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Services().Update(ctx, aiven.ServiceUpdateIn{
+		Project:               opts.Project,
+		Service:               opts.Service,
+		Cloud:                 opts.Cloud,
+		Maintenance:           window,
+		Plan:                  opts.ServiceConfig.Plan,
+		TerminationProtection: protect,
+		UserConfig:            config,
+	})
+}
+
+func powerService(powered bool) func(ctx context.Context) (interface{}, error) {
+	return func(ctx context.Context) (interface{}, error) {
+		client, err := newClient()
+		if err != nil {
+			return nil, err
+		}
+
+		return client.Services().Update(ctx, aiven.ServiceUpdateIn{
+			Project: opts.Project,
+			Service: opts.Service,
+			Powered: &powered,
+		})
+	}
+}
+
+func waitService(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Services().WaitRunning(ctx, aiven.ServiceWaitIn{
+		Project:  opts.Project,
+		Service:  opts.Service,
+		Interval: opts.Interval,
+		Progress: printProgress,
+	})
+}
+
+// printProgress renders service progress to stderr so stdout remains parseable
+func printProgress(p aiven.ServiceProgress) {
+	fmt.Fprintf(os.Stderr, "[%v] %v: %v/%v nodes running\n", p.Elapsed.Round(time.Second), p.State, p.NodesRunning, p.Nodes)
+	for _, node := range p.NodeStates {
+		fmt.Fprintf(os.Stderr, "  %v (%v) %v\n", node.Name, node.Role, node.State)
+		for _, update := range node.ProgressUpdates {
+			if update.Completed {
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "    %v: %.0f%%\n", update.Phase, update.Percent())
+		}
+	}
+}
+
+// plan summarizes a service plan for display
+type plan struct {
+	Plan           string  `json:"plan"`
+	NodeCount      int     `json:"node_count"`
+	DiskSpaceMB    int     `json:"disk_space_mb"`
+	NodeMemoryMB   float64 `json:"node_memory_mb,omitempty"`
+	BackupInterval int     `json:"backup_interval_hours"`
+	BackupCount    int     `json:"backup_count"`
+	HourlyUSD      float64 `json:"hourly_usd,omitempty"`
+	MonthlyUSD     float64 `json:"monthly_usd,omitempty"`
+}
+
+func listPlans(ctx context.Context) (interface{}, error) {
+	// prices are per cloud so there is nothing to sort by without one
+	if opts.Sort == "price" && opts.Cloud == "" {
+		return nil, errors.New("--sort price requires --cloud")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	serviceTypes, err := client.ServiceTypes(ctx, aiven.ServiceTypesIn{
+		Project: opts.Project,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	serviceType, ok := serviceTypes[opts.ServiceConfig.Type]
+	if !ok {
+		return nil, errors.Errorf("unknown service type, %v", opts.ServiceConfig.Type)
+	}
+
+	var plans []plan
+	for _, sp := range serviceType.ServicePlans {
+		p := plan{
+			Plan:           sp.ServicePlan,
+			NodeCount:      sp.NodeCount,
+			DiskSpaceMB:    sp.DiskSpaceMB,
+			BackupInterval: sp.BackupConfig.Interval,
+			BackupCount:    sp.BackupConfig.MaxCount,
+		}
+		if opts.Cloud != "" {
+			price, ok := sp.Price(opts.Cloud)
+			if !ok {
+				continue
+			}
+			p.NodeMemoryMB = sp.Regions[opts.Cloud].NodeMemoryMB
+			p.HourlyUSD = price.HourlyUSD
+			p.MonthlyUSD = price.MonthlyUSD
+		}
+		plans = append(plans, p)
+	}
+
+	switch opts.Sort {
+	case "price":
+		sort.SliceStable(plans, func(i, j int) bool { return plans[i].HourlyUSD < plans[j].HourlyUSD })
+	case "", "name":
+		sort.SliceStable(plans, func(i, j int) bool { return plans[i].Plan < plans[j].Plan })
+	default:
+		return nil, errors.Errorf("unable to sort by %v; expected name or price", opts.Sort)
+	}
+
+	return plans, nil
+}
+
+func serviceLogs(c *cli.Context) error {
+	var re *regexp.Regexp
+	if opts.Grep != "" {
+		v, err := regexp.Compile(opts.Grep)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, errors.Wrapf(err, "invalid --grep, %v", opts.Grep))
+			os.Exit(1)
+		}
+		re = v
+	}
+
+	out := newStream(c, os.Stdout, func(v interface{}) string {
+		entry := v.(aiven.ServiceLogEntry)
+		return fmt.Sprintf("%v %v %v: %v", entry.Time.Format(time.RFC3339), entry.Hostname, entry.Unit, entry.Msg)
+	}, "time", "hostname", "unit", "msg")
+	emit := func(entry aiven.ServiceLogEntry) {
+		if re != nil && !re.MatchString(entry.Msg) {
+			return
+		}
+		if err := out.emit(entry); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	client, err := newClient()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	if opts.Follow {
+		err = client.Services().FollowLogs(ctx, aiven.ServiceFollowLogsIn{
+			Project:  opts.Project,
+			Service:  opts.Service,
+			Limit:    opts.Limit,
+			Interval: opts.Interval,
+			Callback: emit,
+		})
+		if err != nil && ctx.Err() == nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return nil
+	}
+
+	logs, err := client.Services().Logs(ctx, aiven.ServiceLogsIn{
+		Project:   opts.Project,
+		Service:   opts.Service,
+		Limit:     opts.Limit,
+		SortOrder: aiven.SortOrderDesc,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for i := len(logs.Logs) - 1; i >= 0; i-- {
+		emit(logs.Logs[i])
+	}
+
+	return nil
+}
+
+func serviceAlerts(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Services().Alerts(ctx, aiven.ServiceAlertsIn{
+		Project: opts.Project,
+		Service: opts.Service,
+	})
+}
+
+func serviceMetrics(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Services().Metrics(ctx, aiven.ServiceMetricsIn{
+		Project: opts.Project,
+		Service: opts.Service,
+		Period:  opts.Period,
+	})
+}
+
+func listBackups(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Services().Backups(ctx, aiven.ServiceBackupsIn{
+		Project: opts.Project,
+		Service: opts.Service,
+	})
+}
+
+func forkService(ctx context.Context) (interface{}, error) {
+	var recoveryTargetTime *time.Time
+	if opts.Fork.RecoveryTargetTime != "" {
+		t, err := time.Parse(time.RFC3339, opts.Fork.RecoveryTargetTime)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid recovery target time, %v", opts.Fork.RecoveryTargetTime)
+		}
+		recoveryTargetTime = &t
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Services().Fork(ctx, aiven.ServiceForkIn{
+		Project:            opts.Project,
+		Service:            opts.Service,
+		ServiceName:        opts.Fork.Name,
+		RecoveryTargetTime: recoveryTargetTime,
+		BackupName:         opts.Fork.BackupName,
+		Plan:               opts.ServiceConfig.Plan,
+		Cloud:              opts.Cloud,
+	})
+}
+
+func showMaintenance(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Services().Maintenance(ctx, aiven.ServiceMaintenanceIn{
+		Project: opts.Project,
+		Service: opts.Service,
+	})
+}
+
+func setMaintenance(ctx context.Context) (interface{}, error) {
+	if opts.ServiceConfig.MaintenanceDOW == "" || opts.ServiceConfig.MaintenanceTime == "" {
+		return nil, errors.New("--maintenance-dow and --maintenance-time are required")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Services().SetMaintenance(ctx, aiven.ServiceSetMaintenanceIn{
+		Project: opts.Project,
+		Service: opts.Service,
+		DOW:     opts.ServiceConfig.MaintenanceDOW,
+		Time:    opts.ServiceConfig.MaintenanceTime,
+	})
+}
+
+func startMaintenance(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Services().StartMaintenance(ctx, aiven.ServiceStartMaintenanceIn{
+		Project: opts.Project,
+		Service: opts.Service,
+	})
+}
+
+func deleteService(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Services().Delete(ctx, aiven.ServiceDeleteIn{
+		Project: opts.Project,
+		Service: opts.Service,
+	})
+}
+
+func listIPFilter(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Services().IPFilter(ctx, aiven.ServiceIPFilterIn{
+		Project: opts.Project,
+		Service: opts.Service,
+	})
+}
+
+func addIPFilter(ctx context.Context) (interface{}, error) {
+	if len(opts.IPFilter.Networks) == 0 {
+		return nil, errors.New("at least one --network is required")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []aiven.IPFilterEntry
+	for _, network := range opts.IPFilter.Networks {
+		entries = append(entries, aiven.IPFilterEntry{
+			Description: opts.IPFilter.Description,
+			Network:     network,
+		})
+	}
+
+	return client.Services().AddIPFilter(ctx, aiven.ServiceAddIPFilterIn{
+		Project: opts.Project,
+		Service: opts.Service,
+		Entries: entries,
+	})
+}
+
+func removeIPFilter(ctx context.Context) (interface{}, error) {
+	if len(opts.IPFilter.Networks) == 0 {
+		return nil, errors.New("at least one --network is required")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Services().RemoveIPFilter(ctx, aiven.ServiceRemoveIPFilterIn{
+		Project:  opts.Project,
+		Service:  opts.Service,
+		Networks: opts.IPFilter.Networks,
+		Force:    opts.IPFilter.Force,
+	})
+}
@@ -0,0 +1,135 @@
+package lib
+
+import (
+	"context"
+
+	"github.com/savaki/aiven"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var StaticIP = cli.Command{
+	Name:  "static-ip",
+	Usage: "static ip address related commands",
+	Subcommands: cli.Commands{
+		{
+			Name:  "list",
+			Usage: "list static ips",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+			},
+			Action: Do(listStaticIPs, "static_ip_address_id", "ip_address", "cloud_name", "service_name", "state"),
+		},
+		{
+			Name:  "create",
+			Usage: "reserve static ip in cloud",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagCloud,
+			},
+			Action: Do(createStaticIP),
+		},
+		{
+			Name:  "associate",
+			Usage: "associate static ip with service",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagService,
+				flagStaticIPID,
+			},
+			Action: Do(associateStaticIP),
+		},
+		{
+			Name:  "dissociate",
+			Usage: "dissociate static ip from its service",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagStaticIPID,
+			},
+			Action: Do(dissociateStaticIP),
+		},
+		{
+			Name:  "delete",
+			Usage: "release static ip",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagStaticIPID,
+			},
+			Action: Do(deleteStaticIP),
+		},
+	},
+}
+
+func listStaticIPs(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.StaticIPs().List(ctx, aiven.StaticIPListIn{
+		Project: opts.Project,
+	})
+}
+
+func createStaticIP(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.StaticIPs().Create(ctx, aiven.StaticIPCreateIn{
+		Project:   opts.Project,
+		CloudName: opts.Cloud,
+	})
+}
+
+func associateStaticIP(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.StaticIPs().Associate(ctx, aiven.StaticIPAssociateIn{
+		Project:    opts.Project,
+		StaticIPID: opts.StaticIPID,
+		Service:    opts.Service,
+	})
+}
+
+func dissociateStaticIP(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.StaticIPs().Dissociate(ctx, aiven.StaticIPDissociateIn{
+		Project:    opts.Project,
+		StaticIPID: opts.StaticIPID,
+	})
+}
+
+func deleteStaticIP(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.StaticIPs().Delete(ctx, aiven.StaticIPDeleteIn{
+		Project:    opts.Project,
+		StaticIPID: opts.StaticIPID,
+	})
+}
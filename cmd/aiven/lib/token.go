@@ -0,0 +1,87 @@
+package lib
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/savaki/aiven"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var Token = cli.Command{
+	Name:  "token",
+	Usage: "access token related commands",
+	Subcommands: cli.Commands{
+		{
+			Name:  "list",
+			Usage: "list access tokens",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+			},
+			Action: Do(listTokens, "token_prefix", "description", "create_time", "last_used_time", "last_ip", "expiry_time"),
+		},
+		{
+			Name:  "create",
+			Usage: "create access token; the full token is only printed once",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagTokenDescription,
+				flagTokenMaxAge,
+				flagTokenExtendWhenUsed,
+			},
+			Action: Do(createToken),
+		},
+		{
+			Name:  "revoke",
+			Usage: "revoke access token",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagTokenPrefix,
+			},
+			Action: Do(revokeToken),
+		},
+	},
+}
+
+func listTokens(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Tokens().List(ctx)
+}
+
+func createToken(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Tokens().Create(ctx, aiven.TokenCreateIn{
+		Description:    opts.AccessToken.Description,
+		MaxAge:         opts.AccessToken.MaxAge,
+		ExtendWhenUsed: opts.AccessToken.ExtendWhenUsed,
+	})
+}
+
+func revokeToken(ctx context.Context) (interface{}, error) {
+	if opts.AccessToken.Prefix == "" {
+		return nil, errors.New("--prefix is required")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.Tokens().Revoke(ctx, aiven.TokenRevokeIn{
+		TokenPrefix: opts.AccessToken.Prefix,
+	})
+}
@@ -0,0 +1,249 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/savaki/aiven"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var VPC = cli.Command{
+	Name:  "vpc",
+	Usage: "project vpc related commands",
+	Subcommands: cli.Commands{
+		{
+			Name:  "list",
+			Usage: "list project vpcs",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+			},
+			Action: Do(listVPCs, "project_vpc_id", "cloud_name", "network_cidr", "state"),
+		},
+		{
+			Name:  "get",
+			Usage: "describe project vpc",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagVPCID,
+			},
+			Action: Do(getVPC),
+		},
+		{
+			Name:  "create",
+			Usage: "create project vpc",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagCloud,
+				flagNetworkCIDR,
+			},
+			Action: Do(createVPC),
+		},
+		{
+			Name:  "delete",
+			Usage: "delete project vpc",
+			Flags: []cli.Flag{
+				flagEmail,
+				flagPassword,
+				flagOTP,
+				flagProject,
+				flagVPCID,
+			},
+			Action: Do(deleteVPC),
+		},
+		{
+			Name:  "peering",
+			Usage: "manage vpc peering connections",
+			Subcommands: cli.Commands{
+				{
+					Name:  "list",
+					Usage: "list peering connections of vpc",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagVPCID,
+					},
+					Action: Do(listPeerings),
+				},
+				{
+					Name:  "create",
+					Usage: "request peering connection",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagVPCID,
+						flagPeerAccount,
+						flagPeerVPC,
+						flagPeerRegion,
+						flagPeerCIDR,
+					},
+					Action: Do(createPeering),
+				},
+				{
+					Name:  "delete",
+					Usage: "delete peering connection",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagVPCID,
+						flagPeerAccount,
+						flagPeerVPC,
+						flagPeerRegion,
+					},
+					Action: Do(deletePeering),
+				},
+				{
+					Name:  "wait",
+					Usage: "wait for peering connection to become ACTIVE",
+					Flags: []cli.Flag{
+						flagEmail,
+						flagPassword,
+						flagOTP,
+						flagProject,
+						flagVPCID,
+						flagPeerAccount,
+						flagPeerVPC,
+						flagPeerRegion,
+						flagTimeout,
+						flagInterval,
+					},
+					Action: Do(waitPeering),
+				},
+			},
+		},
+	},
+}
+
+func listVPCs(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.VPC().List(ctx, aiven.VPCListIn{
+		Project: opts.Project,
+	})
+}
+
+func getVPC(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.VPC().Get(ctx, aiven.VPCGetIn{
+		Project: opts.Project,
+		VPCID:   opts.VPC.ID,
+	})
+}
+
+func createVPC(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.VPC().Create(ctx, aiven.VPCCreateIn{
+		Project:     opts.Project,
+		CloudName:   opts.Cloud,
+		NetworkCIDR: opts.VPC.NetworkCIDR,
+	})
+}
+
+func deleteVPC(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.VPC().Delete(ctx, aiven.VPCDeleteIn{
+		Project: opts.Project,
+		VPCID:   opts.VPC.ID,
+	})
+}
+
+func listPeerings(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	vpc, err := client.VPC().Get(ctx, aiven.VPCGetIn{
+		Project: opts.Project,
+		VPCID:   opts.VPC.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return vpc.PeeringConnections, nil
+}
+
+func createPeering(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.VPC().CreatePeering(ctx, aiven.VPCCreatePeeringIn{
+		Project:              opts.Project,
+		VPCID:                opts.VPC.ID,
+		PeerCloudAccount:     opts.VPC.PeerAccount,
+		PeerRegion:           opts.VPC.PeerRegion,
+		PeerVPC:              opts.VPC.PeerVPC,
+		UserPeerNetworkCIDRs: opts.VPC.PeerCIDRs,
+	})
+}
+
+func deletePeering(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.VPC().DeletePeering(ctx, aiven.VPCDeletePeeringIn{
+		Project:          opts.Project,
+		VPCID:            opts.VPC.ID,
+		PeerCloudAccount: opts.VPC.PeerAccount,
+		PeerVPC:          opts.VPC.PeerVPC,
+		PeerRegion:       opts.VPC.PeerRegion,
+	})
+}
+
+func waitPeering(ctx context.Context) (interface{}, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.VPC().WaitPeeringActive(ctx, aiven.VPCWaitPeeringIn{
+		Project:          opts.Project,
+		VPCID:            opts.VPC.ID,
+		PeerCloudAccount: opts.VPC.PeerAccount,
+		PeerVPC:          opts.VPC.PeerVPC,
+		PeerRegion:       opts.VPC.PeerRegion,
+		Interval:         opts.Interval,
+		Progress: func(p aiven.PeeringConnection) {
+			fmt.Fprintf(os.Stderr, "%v/%v: %v\n", p.PeerCloudAccount, p.PeerVPC, p.State)
+			if p.StateInfo != nil && p.StateInfo.Message != "" {
+				fmt.Fprintf(os.Stderr, "  %v\n", p.StateInfo.Message)
+			}
+		},
+	})
+}
@@ -20,8 +20,26 @@ func main() {
 	app := cli.NewApp()
 	app.Usage = "console interface to aiven"
 	app.Version = Version
+	app.Flags = lib.GlobalFlags
 	app.Commands = cli.Commands{
 		lib.Kafka,
+		lib.Project,
+		lib.Account,
+		lib.Service,
+		lib.Cloud,
+		lib.Postgres,
+		lib.MySQL,
+		lib.Redis,
+		lib.OpenSearch,
+		lib.Integration,
+		lib.VPC,
+		lib.StaticIP,
+		lib.Token,
+		lib.Billing,
+		lib.Exporter,
+		lib.Configure,
+		lib.Login,
+		lib.Logout,
 	}
 	app.Run(os.Args)
 }
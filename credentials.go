@@ -0,0 +1,243 @@
+package aiven
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrCredentialNotFound is returned by CredentialStore.Get when no secret is stored for the key
+var ErrCredentialNotFound = errors.New("credential not found")
+
+// CredentialStore persists secrets, typically access tokens, by key so they need not be
+// kept in environment variables, shell history or plaintext dotfiles
+type CredentialStore interface {
+	// Get returns the secret stored for key or ErrCredentialNotFound
+	Get(key string) (string, error)
+
+	// Set stores the secret for key, replacing any existing secret
+	Set(key, secret string) error
+
+	// Delete removes the secret for key. Deleting a key that does not exist is not an error.
+	Delete(key string) error
+}
+
+// StoreAuth returns a client authenticated with the access token held by store under key
+func StoreAuth(store CredentialStore, key string) (*Client, error) {
+	token, err := store.Get(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to retrieve token, %v", key)
+	}
+	return NewWithToken(token), nil
+}
+
+// FileStore keeps secrets in a json file readable only by the current user
+type FileStore struct {
+	path string
+}
+
+// NewFileStore returns a store backed by the file at path, which is created on first Set
+func NewFileStore(path string) *FileStore {
+	return &FileStore{
+		path: path,
+	}
+}
+
+func (f *FileStore) read() (map[string]string, error) {
+	return readSecrets(f.path, plain)
+}
+
+func (f *FileStore) Get(key string) (string, error) {
+	secrets, err := f.read()
+	if err != nil {
+		return "", err
+	}
+	secret, ok := secrets[key]
+	if !ok {
+		return "", ErrCredentialNotFound
+	}
+	return secret, nil
+}
+
+func (f *FileStore) Set(key, secret string) error {
+	secrets, err := f.read()
+	if err != nil {
+		return err
+	}
+	secrets[key] = secret
+	return writeSecrets(f.path, secrets, plain)
+}
+
+func (f *FileStore) Delete(key string) error {
+	secrets, err := f.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[key]; !ok {
+		return nil
+	}
+	delete(secrets, key)
+	return writeSecrets(f.path, secrets, plain)
+}
+
+// plain leaves the content of a FileStore as is
+func plain(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+// readSecrets loads the secrets held in path after decoding the file content with
+// decode; a missing file holds no secrets
+func readSecrets(path string, decode func([]byte) ([]byte, error)) (map[string]string, error) {
+	secrets := map[string]string{}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return secrets, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read credentials, %v", path)
+	}
+
+	data, err = decode(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read credentials, %v", path)
+	}
+	if err := json.Unmarshal(data, &secrets); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse credentials, %v", path)
+	}
+
+	return secrets, nil
+}
+
+// writeSecrets saves secrets to path, encoded with encode, with permissions restricted
+// to the current user
+func writeSecrets(path string, secrets map[string]string, encode func([]byte) ([]byte, error)) error {
+	data, err := json.Marshal(secrets)
+	if err != nil {
+		return errors.Wrapf(err, "unable to encode credentials")
+	}
+	data, err = encode(data)
+	if err != nil {
+		return errors.Wrapf(err, "unable to encode credentials")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Wrapf(err, "unable to create credentials dir, %v", filepath.Dir(path))
+	}
+
+	// write then rename so an interrupted write never truncates existing credentials
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrapf(err, "unable to write credentials, %v", path)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return errors.Wrapf(err, "unable to write credentials, %v", path)
+	}
+
+	return nil
+}
+
+// HelperStore delegates to an external credential helper speaking the git credential
+// helper protocol, so any git credential helper e.g. osxkeychain, libsecret or
+// manager can hold aiven tokens. The key is passed as the username for
+// host console.aiven.io.
+//
+// See https://git-scm.com/docs/gitcredentials#_custom_helpers
+type HelperStore struct {
+	helper string
+}
+
+// NewHelperStore returns a store backed by helper. As with git, a bare name such as
+// osxkeychain runs git-credential-osxkeychain, a name prefixed with ! runs as a shell
+// command, and anything else is the path of the helper.
+func NewHelperStore(helper string) *HelperStore {
+	return &HelperStore{
+		helper: helper,
+	}
+}
+
+// command returns the command that runs the helper with the specified action
+func (h *HelperStore) command(action string) *exec.Cmd {
+	switch {
+	case strings.HasPrefix(h.helper, "!"):
+		return exec.Command("sh", "-c", h.helper[1:]+" "+action)
+	case !strings.ContainsRune(h.helper, filepath.Separator):
+		return exec.Command("git-credential-"+h.helper, action)
+	default:
+		return exec.Command(h.helper, action)
+	}
+}
+
+// run sends the attributes to the helper and returns the attributes it replies with
+func (h *HelperStore) run(action string, attrs map[string]string) (map[string]string, error) {
+	var in bytes.Buffer
+	for _, key := range []string{"protocol", "host", "username", "password"} {
+		if value, ok := attrs[key]; ok {
+			in.WriteString(key + "=" + value + "\n")
+		}
+	}
+	in.WriteString("\n")
+
+	var stdout, stderr bytes.Buffer
+	cmd := h.command(action)
+	cmd.Stdin = &in
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "credential helper, %v %v, failed: %v", h.helper, action, strings.TrimSpace(stderr.String()))
+	}
+
+	out := map[string]string{}
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		if i := strings.Index(line, "="); i > 0 {
+			out[line[:i]] = line[i+1:]
+		}
+	}
+
+	return out, nil
+}
+
+func (h *HelperStore) attrs(key string) map[string]string {
+	return map[string]string{
+		"protocol": "https",
+		"host":     "console.aiven.io",
+		"username": key,
+	}
+}
+
+func (h *HelperStore) Get(key string) (string, error) {
+	out, err := h.run("get", h.attrs(key))
+	if err != nil {
+		return "", err
+	}
+	secret, ok := out["password"]
+	if !ok || secret == "" {
+		return "", ErrCredentialNotFound
+	}
+	return secret, nil
+}
+
+func (h *HelperStore) Set(key, secret string) error {
+	attrs := h.attrs(key)
+	attrs["password"] = secret
+	_, err := h.run("store", attrs)
+	return err
+}
+
+func (h *HelperStore) Delete(key string) error {
+	_, err := h.run("erase", h.attrs(key))
+	return err
+}
@@ -0,0 +1,136 @@
+package aiven
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+)
+
+// scrypt parameters recommended for interactive logins
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLen      = 16
+)
+
+// ErrBadPassphrase is returned when an EncryptedFileStore cannot be decrypted
+var ErrBadPassphrase = errors.New("unable to decrypt credentials; wrong passphrase or corrupt file")
+
+// EncryptedFileStore keeps secrets in a file encrypted with AES-256-GCM under a key
+// derived from a passphrase with scrypt
+type EncryptedFileStore struct {
+	path       string
+	passphrase []byte
+}
+
+// NewEncryptedFileStore returns a store backed by the encrypted file at path
+func NewEncryptedFileStore(path string, passphrase []byte) *EncryptedFileStore {
+	return &EncryptedFileStore{
+		path:       path,
+		passphrase: passphrase,
+	}
+}
+
+// sealedCredentials is the content of an encrypted credentials file
+type sealedCredentials struct {
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func (e *EncryptedFileStore) gcm(salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(e.passphrase, salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to derive key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to create cipher")
+	}
+	return cipher.NewGCM(block)
+}
+
+func (e *EncryptedFileStore) decrypt(data []byte) ([]byte, error) {
+	var sealed sealedCredentials
+	if err := json.Unmarshal(data, &sealed); err != nil {
+		return nil, ErrBadPassphrase
+	}
+
+	gcm, err := e.gcm(sealed.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed.Nonce) != gcm.NonceSize() {
+		return nil, ErrBadPassphrase
+	}
+
+	plaintext, err := gcm.Open(nil, sealed.Nonce, sealed.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrBadPassphrase
+	}
+	return plaintext, nil
+}
+
+// encrypt seals data with a fresh salt and nonce on every write
+func (e *EncryptedFileStore) encrypt(data []byte) ([]byte, error) {
+	salt := make([]byte, saltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, errors.Wrapf(err, "unable to generate salt")
+	}
+
+	gcm, err := e.gcm(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrapf(err, "unable to generate nonce")
+	}
+
+	return json.Marshal(sealedCredentials{
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, data, nil),
+	})
+}
+
+func (e *EncryptedFileStore) Get(key string) (string, error) {
+	secrets, err := readSecrets(e.path, e.decrypt)
+	if err != nil {
+		return "", err
+	}
+	secret, ok := secrets[key]
+	if !ok {
+		return "", ErrCredentialNotFound
+	}
+	return secret, nil
+}
+
+func (e *EncryptedFileStore) Set(key, secret string) error {
+	secrets, err := readSecrets(e.path, e.decrypt)
+	if err != nil {
+		return err
+	}
+	secrets[key] = secret
+	return writeSecrets(e.path, secrets, e.encrypt)
+}
+
+func (e *EncryptedFileStore) Delete(key string) error {
+	secrets, err := readSecrets(e.path, e.decrypt)
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[key]; !ok {
+		return nil
+	}
+	delete(secrets, key)
+	return writeSecrets(e.path, secrets, e.encrypt)
+}
@@ -0,0 +1,85 @@
+package aiven_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+// testCredentialStore exercises the behaviour every CredentialStore must share
+func testCredentialStore(t *testing.T, store aiven.CredentialStore) {
+	_, err := store.Get("prod")
+	assert.Equal(t, aiven.ErrCredentialNotFound, errors.Cause(err))
+
+	assert.Nil(t, store.Set("prod", "token-1"))
+	assert.Nil(t, store.Set("staging", "token-2"))
+	assert.Nil(t, store.Set("prod", "token-3"))
+
+	secret, err := store.Get("prod")
+	assert.Nil(t, err)
+	assert.Equal(t, "token-3", secret)
+
+	secret, err = store.Get("staging")
+	assert.Nil(t, err)
+	assert.Equal(t, "token-2", secret)
+
+	assert.Nil(t, store.Delete("prod"))
+	assert.Nil(t, store.Delete("prod"))
+
+	_, err = store.Get("prod")
+	assert.Equal(t, aiven.ErrCredentialNotFound, errors.Cause(err))
+
+	client, err := aiven.StoreAuth(store, "staging")
+	assert.Nil(t, err)
+	assert.Equal(t, "token-2", client.Token())
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aiven", "credentials.json")
+	testCredentialStore(t, aiven.NewFileStore(path))
+
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestEncryptedFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.enc")
+	testCredentialStore(t, aiven.NewEncryptedFileStore(path, []byte("correct horse")))
+
+	data, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), "token-2")
+
+	_, err = aiven.NewEncryptedFileStore(path, []byte("battery staple")).Get("staging")
+	assert.Equal(t, aiven.ErrBadPassphrase, errors.Cause(err))
+}
+
+func TestHelperStore(t *testing.T) {
+	dir := t.TempDir()
+
+	// a minimal helper that keeps one password per username in dir
+	helper := filepath.Join(dir, "helper.sh")
+	script := `#!/bin/sh
+while IFS='=' read -r key value; do
+	[ -z "$key" ] && break
+	eval "attr_$key=\$value"
+done
+file="` + dir + `/$attr_username"
+case "$1" in
+get) [ -f "$file" ] && echo "password=$(cat "$file")" ;;
+store) printf '%s' "$attr_password" > "$file" ;;
+erase) rm -f "$file" ;;
+esac
+exit 0
+`
+	assert.Nil(t, ioutil.WriteFile(helper, []byte(script), 0700))
+
+	testCredentialStore(t, aiven.NewHelperStore(helper))
+	testCredentialStore(t, aiven.NewHelperStore("!"+helper))
+}
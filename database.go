@@ -0,0 +1,77 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// database is the representation of a logical database shared by postgres and mysql
+type database struct {
+	DatabaseName string `json:"database_name"`
+	LCCollate    string `json:"lc_collate,omitempty"`
+	LCCType      string `json:"lc_ctype,omitempty"`
+}
+
+// listDatabases returns the logical databases within a postgres or mysql service
+func (c *Client) listDatabases(ctx context.Context, project, service string) ([]database, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/db", project, service)
+	out := struct {
+		apiErrors
+		Databases []database `json:"databases"`
+	}{}
+	if err := c.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list databases for project:service, %v:%v", project, service)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list databases for project:service, %v:%v", project, service)
+	}
+
+	return out.Databases, nil
+}
+
+// createDatabase creates a logical database. Creating a database that already exists is not an error.
+func (c *Client) createDatabase(ctx context.Context, project, service string, db database) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/db", project, service)
+	in := struct {
+		Database  string `json:"database"`
+		LCCollate string `json:"lc_collate,omitempty"`
+		LCCType   string `json:"lc_ctype,omitempty"`
+	}{
+		Database:  db.DatabaseName,
+		LCCollate: db.LCCollate,
+		LCCType:   db.LCCType,
+	}
+	out := apiErrors{}
+	if err := c.Post(ctx, u, in, &out); err != nil {
+		return errors.Wrapf(err, "unable to create database, %v, for project:service, %v:%v", db.DatabaseName, project, service)
+	}
+
+	for _, e := range out.Errors {
+		if e.Status == http.StatusConflict {
+			return nil
+		}
+		return errors.Wrapf(errors.New(e.Message), "unable to create database, %v, for project:service, %v:%v", db.DatabaseName, project, service)
+	}
+
+	return nil
+}
+
+// deleteDatabase removes a logical database. Deleting a database that does not exist is not an error.
+func (c *Client) deleteDatabase(ctx context.Context, project, service, name string) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/db/%v", project, service, name)
+	out := apiErrors{}
+	if err := c.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to delete database, %v, for project:service, %v:%v", name, project, service)
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to delete database, %v, for project:service, %v:%v", name, project, service)
+	}
+
+	return nil
+}
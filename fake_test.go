@@ -0,0 +1,27 @@
+package aiven_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+// fakeAPI serves the requests of clients using the default transport, e.g. those
+// returned by aiven.NewWithToken, from handler for the duration of the test
+func fakeAPI(t *testing.T, handler http.HandlerFunc) {
+	original := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w.Result(), nil
+	})
+	t.Cleanup(func() {
+		http.DefaultTransport = original
+	})
+}
@@ -0,0 +1,217 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// IntegrationType identifies the kind of a service integration
+type IntegrationType string
+
+const (
+	IntegrationTypeDatadog                   IntegrationType = "datadog"
+	IntegrationTypeExternalElasticsearchLogs IntegrationType = "external_elasticsearch_logs"
+	IntegrationTypeKafkaConnect              IntegrationType = "kafka_connect"
+	IntegrationTypeKafkaLogs                 IntegrationType = "kafka_logs"
+	IntegrationTypeKafkaMirrorMaker          IntegrationType = "kafka_mirrormaker"
+	IntegrationTypeLogs                      IntegrationType = "logs"
+	IntegrationTypeMetrics                   IntegrationType = "metrics"
+	IntegrationTypePrometheus                IntegrationType = "prometheus"
+	IntegrationTypeReadReplica               IntegrationType = "read_replica"
+	IntegrationTypeRsyslog                   IntegrationType = "rsyslog"
+	IntegrationTypeSchemaRegistryProxy       IntegrationType = "schema_registry_proxy"
+)
+
+// EndpointType identifies the kind of an integration endpoint
+type EndpointType string
+
+const (
+	EndpointTypeDatadog                   EndpointType = "datadog"
+	EndpointTypeExternalElasticsearchLogs EndpointType = "external_elasticsearch_logs"
+	EndpointTypeExternalKafka             EndpointType = "external_kafka"
+	EndpointTypeExternalSchemaRegistry    EndpointType = "external_schema_registry"
+	EndpointTypePrometheus                EndpointType = "prometheus"
+	EndpointTypeRsyslog                   EndpointType = "rsyslog"
+)
+
+// Integrations provides an api into aiven service integrations and integration endpoints
+type Integrations struct {
+	client *Client
+}
+
+// newIntegrations accepts a valid aiven client and returns access to the Integrations api
+func newIntegrations(client *Client) *Integrations {
+	return &Integrations{
+		client: client,
+	}
+}
+
+// ServiceIntegration connects a service to another service or to an external endpoint
+type ServiceIntegration struct {
+	Active               bool                   `json:"active"`
+	Description          string                 `json:"description"`
+	DestEndpoint         string                 `json:"dest_endpoint,omitempty"`
+	DestEndpointID       string                 `json:"dest_endpoint_id,omitempty"`
+	DestProject          string                 `json:"dest_project"`
+	DestService          string                 `json:"dest_service,omitempty"`
+	DestServiceType      string                 `json:"dest_service_type"`
+	Enabled              bool                   `json:"enabled"`
+	IntegrationStatus    map[string]interface{} `json:"integration_status,omitempty"`
+	IntegrationType      IntegrationType        `json:"integration_type"`
+	ServiceIntegrationID string                 `json:"service_integration_id"`
+	SourceEndpoint       string                 `json:"source_endpoint,omitempty"`
+	SourceEndpointID     string                 `json:"source_endpoint_id,omitempty"`
+	SourceProject        string                 `json:"source_project"`
+	SourceService        string                 `json:"source_service,omitempty"`
+	SourceServiceType    string                 `json:"source_service_type"`
+	UserConfig           map[string]interface{} `json:"user_config,omitempty"`
+}
+
+// IntegrationEndpoint represents an external system e.g. datadog that services may integrate with
+type IntegrationEndpoint struct {
+	EndpointConfig map[string]interface{} `json:"endpoint_config,omitempty"`
+	EndpointID     string                 `json:"endpoint_id"`
+	EndpointName   string                 `json:"endpoint_name"`
+	EndpointType   EndpointType           `json:"endpoint_type"`
+	UserConfig     map[string]interface{} `json:"user_config,omitempty"`
+}
+
+type IntegrationListIn struct {
+	Project string
+	Service string
+}
+
+// List returns the integrations of the service
+func (i *Integrations) List(ctx context.Context, in IntegrationListIn) ([]ServiceIntegration, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/integration", in.Project, in.Service)
+	out := struct {
+		apiErrors
+		Integrations []ServiceIntegration `json:"service_integrations"`
+	}{}
+	if err := i.client.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list integrations for project:service, %v:%v", in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list integrations for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	return out.Integrations, nil
+}
+
+type IntegrationCreateIn struct {
+	Project          string                 `json:"-"`
+	DestEndpointID   string                 `json:"dest_endpoint_id,omitempty"`
+	DestService      string                 `json:"dest_service,omitempty"`
+	IntegrationType  IntegrationType        `json:"integration_type"`
+	SourceEndpointID string                 `json:"source_endpoint_id,omitempty"`
+	SourceService    string                 `json:"source_service,omitempty"`
+	UserConfig       map[string]interface{} `json:"user_config,omitempty"`
+}
+
+// Create integrates a source service or endpoint with a destination service or endpoint
+func (i *Integrations) Create(ctx context.Context, in IntegrationCreateIn) (ServiceIntegration, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/integration", in.Project)
+	out := struct {
+		apiErrors
+		Integration ServiceIntegration `json:"service_integration"`
+	}{}
+	if err := i.client.Post(ctx, u, in, &out); err != nil {
+		return ServiceIntegration{}, errors.Wrapf(err, "unable to create %v integration for project, %v", in.IntegrationType, in.Project)
+	}
+	if err := out.err(); err != nil {
+		return ServiceIntegration{}, errors.Wrapf(err, "unable to create %v integration for project, %v", in.IntegrationType, in.Project)
+	}
+
+	return out.Integration, nil
+}
+
+type IntegrationDeleteIn struct {
+	Project       string
+	IntegrationID string
+}
+
+// Delete removes an integration. Deleting an integration that does not exist is not an error.
+func (i *Integrations) Delete(ctx context.Context, in IntegrationDeleteIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/integration/%v", in.Project, in.IntegrationID)
+	out := apiErrors{}
+	if err := i.client.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to delete integration, %v, for project, %v", in.IntegrationID, in.Project)
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to delete integration, %v, for project, %v", in.IntegrationID, in.Project)
+	}
+
+	return nil
+}
+
+type IntegrationListEndpointsIn struct {
+	Project string
+}
+
+// ListEndpoints returns the integration endpoints defined in the project
+func (i *Integrations) ListEndpoints(ctx context.Context, in IntegrationListEndpointsIn) ([]IntegrationEndpoint, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/integration_endpoint", in.Project)
+	out := struct {
+		apiErrors
+		Endpoints []IntegrationEndpoint `json:"service_integration_endpoints"`
+	}{}
+	if err := i.client.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list integration endpoints for project, %v", in.Project)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list integration endpoints for project, %v", in.Project)
+	}
+
+	return out.Endpoints, nil
+}
+
+type IntegrationCreateEndpointIn struct {
+	Project      string                 `json:"-"`
+	EndpointName string                 `json:"endpoint_name"`
+	EndpointType EndpointType           `json:"endpoint_type"`
+	UserConfig   map[string]interface{} `json:"user_config"`
+}
+
+// CreateEndpoint defines an external system, e.g. a datadog account, that services may integrate with
+func (i *Integrations) CreateEndpoint(ctx context.Context, in IntegrationCreateEndpointIn) (IntegrationEndpoint, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/integration_endpoint", in.Project)
+	out := struct {
+		apiErrors
+		Endpoint IntegrationEndpoint `json:"service_integration_endpoint"`
+	}{}
+	if err := i.client.Post(ctx, u, in, &out); err != nil {
+		return IntegrationEndpoint{}, errors.Wrapf(err, "unable to create integration endpoint, %v, for project, %v", in.EndpointName, in.Project)
+	}
+	if err := out.err(); err != nil {
+		return IntegrationEndpoint{}, errors.Wrapf(err, "unable to create integration endpoint, %v, for project, %v", in.EndpointName, in.Project)
+	}
+
+	return out.Endpoint, nil
+}
+
+type IntegrationDeleteEndpointIn struct {
+	Project    string
+	EndpointID string
+}
+
+// DeleteEndpoint removes an integration endpoint. Deleting an endpoint that does not exist is not an error.
+func (i *Integrations) DeleteEndpoint(ctx context.Context, in IntegrationDeleteEndpointIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/integration_endpoint/%v", in.Project, in.EndpointID)
+	out := apiErrors{}
+	if err := i.client.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to delete integration endpoint, %v, for project, %v", in.EndpointID, in.Project)
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to delete integration endpoint, %v, for project, %v", in.EndpointID, in.Project)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package aiven_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegrations(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+	service := os.Getenv("AIVEN_SERVICE")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+	if service == "" {
+		t.Skip("AIVEN_SERVICE not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	api := client.Integrations()
+	integrations, err := api.List(context.Background(), aiven.IntegrationListIn{
+		Project: project,
+		Service: service,
+	})
+	assert.Nil(t, err)
+
+	endpoints, err := api.ListEndpoints(context.Background(), aiven.IntegrationListEndpointsIn{
+		Project: project,
+	})
+	assert.Nil(t, err)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(integrations)
+	encoder.Encode(endpoints)
+}
@@ -37,17 +37,15 @@ type KafkaListTopicsIn struct {
 
 // ListTopics returns the list of all topics
 func (k *Kafka) ListTopics(ctx context.Context, in KafkaListTopicsIn) ([]KafkaTopic, error) {
-	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v", in.Project, in.Service)
-	out := struct {
-		Service struct {
-			Topics []KafkaTopic
-		}
-	}{}
-	if err := k.client.Get(ctx, u, &out); err != nil {
+	service, err := k.client.Services().Get(ctx, ServiceGetIn{
+		Project: in.Project,
+		Service: in.Service,
+	})
+	if err != nil {
 		return nil, errors.Wrapf(err, "unable to retrieve topics for project:service, %v:%v", in.Project, in.Service)
 	}
 
-	return out.Service.Topics, nil
+	return service.Topics, nil
 }
 
 type KafkaCreateTopicIn struct {
@@ -140,6 +138,20 @@ type KafkaPartitionInfo struct {
 	Size           int64                    `json:"size"`
 }
 
+// ConsumerLag returns the number of messages each consumer group is behind the latest
+// offset of the partition, keyed by group name
+func (p KafkaPartitionInfo) ConsumerLag() map[string]int64 {
+	lag := map[string]int64{}
+	for _, group := range p.ConsumerGroups {
+		v := p.LatestOffset - group.Offset
+		if v < 0 {
+			v = 0
+		}
+		lag[group.GroupName] = v
+	}
+	return lag
+}
+
 type KafkaTopicInfo struct {
 	CleanupPolicy     string               `json:"cleanup_policy"`
 	MinInsyncReplicas int                  `json:"min_insync_replicas"`
@@ -41,3 +41,17 @@ func TestTopics(t *testing.T) {
 	encoder.SetIndent("", "  ")
 	encoder.Encode(out)
 }
+
+func TestConsumerLag(t *testing.T) {
+	partition := aiven.KafkaPartitionInfo{
+		LatestOffset: 100,
+		ConsumerGroups: []aiven.KafkaConsumerGroupInfo{
+			{GroupName: "fast", Offset: 100},
+			{GroupName: "slow", Offset: 40},
+			{GroupName: "ahead", Offset: 120},
+		},
+	}
+
+	lag := partition.ConsumerLag()
+	assert.Equal(t, map[string]int64{"fast": 0, "slow": 60, "ahead": 0}, lag)
+}
@@ -0,0 +1,163 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MemberType is the role of a user within a project
+type MemberType string
+
+const (
+	MemberTypeAdmin     MemberType = "admin"
+	MemberTypeDeveloper MemberType = "developer"
+	MemberTypeOperator  MemberType = "operator"
+	MemberTypeReadOnly  MemberType = "read_only"
+)
+
+// Members provides an api into the users and invitations of aiven projects
+type Members struct {
+	client *Client
+}
+
+// newMembers accepts a valid aiven client and returns access to the Members api
+func newMembers(client *Client) *Members {
+	return &Members{
+		client: client,
+	}
+}
+
+// Member represents a user with access to a project
+type Member struct {
+	BillingContact bool       `json:"billing_contact"`
+	CreateTime     time.Time  `json:"create_time"`
+	MemberType     MemberType `json:"member_type"`
+	RealName       string     `json:"real_name"`
+	TeamID         string     `json:"team_id,omitempty"`
+	TeamName       string     `json:"team_name,omitempty"`
+	UserEmail      string     `json:"user_email"`
+}
+
+// Invitation represents a pending invitation to join a project
+type Invitation struct {
+	InviteTime        time.Time  `json:"invite_time"`
+	InvitedUserEmail  string     `json:"invited_user_email"`
+	InvitingUserEmail string     `json:"inviting_user_email"`
+	MemberType        MemberType `json:"member_type"`
+}
+
+type MemberListIn struct {
+	Project string
+}
+
+type MemberListOut struct {
+	Invitations []Invitation `json:"invitations"`
+	Users       []Member     `json:"users"`
+}
+
+// List returns the users of the project along with any pending invitations
+func (m *Members) List(ctx context.Context, in MemberListIn) (MemberListOut, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/users", in.Project)
+	out := struct {
+		apiErrors
+		MemberListOut
+	}{}
+	if err := m.client.Get(ctx, u, &out); err != nil {
+		return MemberListOut{}, errors.Wrapf(err, "unable to list members for project, %v", in.Project)
+	}
+	if err := out.err(); err != nil {
+		return MemberListOut{}, errors.Wrapf(err, "unable to list members for project, %v", in.Project)
+	}
+
+	return out.MemberListOut, nil
+}
+
+type MemberInviteIn struct {
+	Project    string     `json:"-"`
+	Email      string     `json:"user_email"`
+	MemberType MemberType `json:"member_type"`
+}
+
+// Invite sends an email invitation to join the project with the specified role
+func (m *Members) Invite(ctx context.Context, in MemberInviteIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/invite", in.Project)
+	out := apiErrors{}
+	if err := m.client.Post(ctx, u, in, &out); err != nil {
+		return errors.Wrapf(err, "unable to invite user, %v, to project, %v", in.Email, in.Project)
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to invite user, %v, to project, %v", in.Email, in.Project)
+	}
+
+	return nil
+}
+
+type MemberSetRoleIn struct {
+	Project    string     `json:"-"`
+	Email      string     `json:"-"`
+	MemberType MemberType `json:"member_type"`
+}
+
+// SetRole changes the role of an existing project user
+func (m *Members) SetRole(ctx context.Context, in MemberSetRoleIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/user/%v", in.Project, url.PathEscape(in.Email))
+	out := apiErrors{}
+	if err := m.client.Put(ctx, u, in, &out); err != nil {
+		return errors.Wrapf(err, "unable to change role of user, %v, in project, %v", in.Email, in.Project)
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to change role of user, %v, in project, %v", in.Email, in.Project)
+	}
+
+	return nil
+}
+
+type MemberRemoveIn struct {
+	Project string
+	Email   string
+}
+
+// Remove revokes the access of a user to the project. Removing a user that is not a
+// member is not an error.
+func (m *Members) Remove(ctx context.Context, in MemberRemoveIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/user/%v", in.Project, url.PathEscape(in.Email))
+	out := apiErrors{}
+	if err := m.client.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to remove user, %v, from project, %v", in.Email, in.Project)
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to remove user, %v, from project, %v", in.Email, in.Project)
+	}
+
+	return nil
+}
+
+type MemberCancelInviteIn struct {
+	Project string
+	Email   string
+}
+
+// CancelInvite withdraws a pending invitation. Cancelling an invitation that does not
+// exist is not an error.
+func (m *Members) CancelInvite(ctx context.Context, in MemberCancelInviteIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/invite/%v", in.Project, url.PathEscape(in.Email))
+	out := apiErrors{}
+	if err := m.client.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to cancel invitation of user, %v, to project, %v", in.Email, in.Project)
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to cancel invitation of user, %v, to project, %v", in.Email, in.Project)
+	}
+
+	return nil
+}
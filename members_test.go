@@ -0,0 +1,33 @@
+package aiven_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMembers(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	out, err := client.Members().List(context.Background(), aiven.MemberListIn{
+		Project: project,
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, out.Users)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(out)
+}
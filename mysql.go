@@ -0,0 +1,65 @@
+package aiven
+
+import (
+	"context"
+)
+
+// MySQL provides an api into aiven mysql
+type MySQL struct {
+	client *Client
+}
+
+// newMySQL accepts a valid aiven client and returns access to the MySQL api
+func newMySQL(client *Client) *MySQL {
+	return &MySQL{
+		client: client,
+	}
+}
+
+// MySQLDatabase represents a database within a mysql service
+type MySQLDatabase struct {
+	DatabaseName string `json:"database_name"`
+}
+
+type MySQLListDatabasesIn struct {
+	Project string
+	Service string
+}
+
+// ListDatabases returns the databases within the service
+func (m *MySQL) ListDatabases(ctx context.Context, in MySQLListDatabasesIn) ([]MySQLDatabase, error) {
+	databases, err := m.client.listDatabases(ctx, in.Project, in.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []MySQLDatabase
+	for _, db := range databases {
+		out = append(out, MySQLDatabase{DatabaseName: db.DatabaseName})
+	}
+	return out, nil
+}
+
+type MySQLCreateDatabaseIn struct {
+	Project  string
+	Service  string
+	Database string
+}
+
+// CreateDatabase creates a database. Creating a database that already exists is not an error.
+func (m *MySQL) CreateDatabase(ctx context.Context, in MySQLCreateDatabaseIn) error {
+	return m.client.createDatabase(ctx, in.Project, in.Service, database{
+		DatabaseName: in.Database,
+	})
+}
+
+type MySQLDeleteDatabaseIn struct {
+	Project  string
+	Service  string
+	Database string
+}
+
+// DeleteDatabase removes a database. Deleting a database that does not exist is not an error.
+func (m *MySQL) DeleteDatabase(ctx context.Context, in MySQLDeleteDatabaseIn) error {
+	return m.client.deleteDatabase(ctx, in.Project, in.Service, in.Database)
+}
@@ -0,0 +1,37 @@
+package aiven_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLDatabases(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+	service := os.Getenv("AIVEN_MYSQL_SERVICE")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+	if service == "" {
+		t.Skip("AIVEN_MYSQL_SERVICE not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	out, err := client.MySQL().ListDatabases(context.Background(), aiven.MySQLListDatabasesIn{
+		Project: project,
+		Service: service,
+	})
+	assert.Nil(t, err)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(out)
+}
@@ -0,0 +1,215 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	OpenSearchPermissionAdmin     = "admin"
+	OpenSearchPermissionDeny      = "deny"
+	OpenSearchPermissionRead      = "read"
+	OpenSearchPermissionReadWrite = "readwrite"
+	OpenSearchPermissionWrite     = "write"
+)
+
+// OpenSearch provides an api into aiven opensearch
+type OpenSearch struct {
+	client *Client
+}
+
+// newOpenSearch accepts a valid aiven client and returns access to the OpenSearch api
+func newOpenSearch(client *Client) *OpenSearch {
+	return &OpenSearch{
+		client: client,
+	}
+}
+
+// OpenSearchIndex describes an index within an opensearch service
+type OpenSearchIndex struct {
+	CreateTime          time.Time `json:"create_time"`
+	Docs                int64     `json:"docs"`
+	Health              string    `json:"health"`
+	IndexName           string    `json:"index_name"`
+	NumberOfReplicas    int       `json:"number_of_replicas"`
+	NumberOfShards      int       `json:"number_of_shards"`
+	ReadOnlyAllowDelete bool      `json:"read_only_allow_delete"`
+	Size                int64     `json:"size"`
+	Status              string    `json:"status"`
+}
+
+// FilterIndexes returns the indexes whose name matches the glob pattern, e.g. logs-*,
+// and that were created before the specified time. Indexes without a create time are
+// never matched as their age is unknown.
+func FilterIndexes(indexes []OpenSearchIndex, pattern string, before time.Time) ([]OpenSearchIndex, error) {
+	var matched []OpenSearchIndex
+	for _, index := range indexes {
+		ok, err := path.Match(pattern, index.IndexName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid index pattern, %v", pattern)
+		}
+		if ok && !index.CreateTime.IsZero() && index.CreateTime.Before(before) {
+			matched = append(matched, index)
+		}
+	}
+	return matched, nil
+}
+
+type OpenSearchListIndexesIn struct {
+	Project string
+	Service string
+}
+
+// ListIndexes returns the indexes within the service
+func (o *OpenSearch) ListIndexes(ctx context.Context, in OpenSearchListIndexesIn) ([]OpenSearchIndex, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/index", in.Project, in.Service)
+	out := struct {
+		apiErrors
+		Indexes []OpenSearchIndex `json:"indexes"`
+	}{}
+	if err := o.client.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list indexes for project:service, %v:%v", in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list indexes for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	return out.Indexes, nil
+}
+
+type OpenSearchDeleteIndexIn struct {
+	Project string
+	Service string
+	Index   string
+}
+
+// DeleteIndex removes an index. Deleting an index that does not exist is not an error.
+func (o *OpenSearch) DeleteIndex(ctx context.Context, in OpenSearchDeleteIndexIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/index/%v", in.Project, in.Service, in.Index)
+	out := apiErrors{}
+	if err := o.client.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to delete index, %v, for project:service, %v:%v", in.Index, in.Project, in.Service)
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to delete index, %v, for project:service, %v:%v", in.Index, in.Project, in.Service)
+	}
+
+	return nil
+}
+
+type OpenSearchPruneIndexesIn struct {
+	Project   string
+	Service   string
+	Pattern   string        // Pattern is a glob matched against index names e.g. logs-*
+	OlderThan time.Duration // OlderThan is the minimum age of indexes to delete
+	DryRun    bool          // DryRun returns the indexes that would be deleted without deleting them
+}
+
+// PruneIndexes deletes the indexes matching Pattern that are older than OlderThan and
+// returns the indexes deleted. If a deletion fails, the indexes deleted before it are
+// returned along with the error.
+func (o *OpenSearch) PruneIndexes(ctx context.Context, in OpenSearchPruneIndexesIn) ([]OpenSearchIndex, error) {
+	indexes, err := o.ListIndexes(ctx, OpenSearchListIndexesIn{
+		Project: in.Project,
+		Service: in.Service,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	expired, err := FilterIndexes(indexes, in.Pattern, time.Now().Add(-in.OlderThan))
+	if err != nil {
+		return nil, err
+	}
+	if in.DryRun {
+		return expired, nil
+	}
+
+	for i, index := range expired {
+		if err := o.DeleteIndex(ctx, OpenSearchDeleteIndexIn{
+			Project: in.Project,
+			Service: in.Service,
+			Index:   index.IndexName,
+		}); err != nil {
+			return expired[:i], err
+		}
+	}
+
+	return expired, nil
+}
+
+// OpenSearchACLRule grants a permission on the indexes matching Index
+type OpenSearchACLRule struct {
+	Index      string `json:"index"`
+	Permission string `json:"permission"`
+}
+
+// OpenSearchACL holds the rules for a single user
+type OpenSearchACL struct {
+	Rules    []OpenSearchACLRule `json:"rules"`
+	Username string              `json:"username"`
+}
+
+// OpenSearchACLConfig holds the access control configuration of an opensearch service
+type OpenSearchACLConfig struct {
+	ACLs        []OpenSearchACL `json:"acls"`
+	Enabled     bool            `json:"enabled"`
+	ExtendedACL bool            `json:"extendedAcl"`
+}
+
+type OpenSearchGetACLIn struct {
+	Project string
+	Service string
+}
+
+// GetACL returns the access control configuration of the service
+func (o *OpenSearch) GetACL(ctx context.Context, in OpenSearchGetACLIn) (OpenSearchACLConfig, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/opensearch/acl", in.Project, in.Service)
+	out := struct {
+		apiErrors
+		Config OpenSearchACLConfig `json:"opensearch_acl_config"`
+	}{}
+	if err := o.client.Get(ctx, u, &out); err != nil {
+		return OpenSearchACLConfig{}, errors.Wrapf(err, "unable to retrieve acl for project:service, %v:%v", in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return OpenSearchACLConfig{}, errors.Wrapf(err, "unable to retrieve acl for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	return out.Config, nil
+}
+
+type OpenSearchSetACLIn struct {
+	Project string
+	Service string
+	Config  OpenSearchACLConfig
+}
+
+// SetACL replaces the access control configuration of the service
+func (o *OpenSearch) SetACL(ctx context.Context, in OpenSearchSetACLIn) (OpenSearchACLConfig, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/opensearch/acl", in.Project, in.Service)
+	body := struct {
+		Config OpenSearchACLConfig `json:"opensearch_acl_config"`
+	}{
+		Config: in.Config,
+	}
+	out := struct {
+		apiErrors
+		Config OpenSearchACLConfig `json:"opensearch_acl_config"`
+	}{}
+	if err := o.client.Put(ctx, u, body, &out); err != nil {
+		return OpenSearchACLConfig{}, errors.Wrapf(err, "unable to update acl for project:service, %v:%v", in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return OpenSearchACLConfig{}, errors.Wrapf(err, "unable to update acl for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	return out.Config, nil
+}
@@ -0,0 +1,110 @@
+package aiven_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenSearchIndexes(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+	service := os.Getenv("AIVEN_OPENSEARCH_SERVICE")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+	if service == "" {
+		t.Skip("AIVEN_OPENSEARCH_SERVICE not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	api := client.OpenSearch()
+	indexes, err := api.ListIndexes(context.Background(), aiven.OpenSearchListIndexesIn{
+		Project: project,
+		Service: service,
+	})
+	assert.Nil(t, err)
+
+	acl, err := api.GetACL(context.Background(), aiven.OpenSearchGetACLIn{
+		Project: project,
+		Service: service,
+	})
+	assert.Nil(t, err)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(indexes)
+	encoder.Encode(acl)
+}
+
+func TestFilterIndexes(t *testing.T) {
+	now := time.Now()
+	indexes := []aiven.OpenSearchIndex{
+		{IndexName: "logs-2026.01.01", CreateTime: now.Add(-60 * 24 * time.Hour)},
+		{IndexName: "logs-2026.10.18", CreateTime: now.Add(-24 * time.Hour)},
+		{IndexName: "metrics-2026.01.01", CreateTime: now.Add(-60 * 24 * time.Hour)},
+		{IndexName: "logs-unknown"},
+	}
+
+	matched, err := aiven.FilterIndexes(indexes, "logs-*", now.Add(-30*24*time.Hour))
+	assert.Nil(t, err)
+	assert.Len(t, matched, 1)
+	assert.Equal(t, "logs-2026.01.01", matched[0].IndexName)
+
+	matched, err = aiven.FilterIndexes(indexes, "*", now)
+	assert.Nil(t, err)
+	assert.Len(t, matched, 3)
+
+	// an index without a create time has an unknown age and is never matched
+	matched, err = aiven.FilterIndexes(indexes, "logs-unknown", now)
+	assert.Nil(t, err)
+	assert.Len(t, matched, 0)
+
+	_, err = aiven.FilterIndexes(indexes, "[", now)
+	assert.NotNil(t, err)
+}
+
+func TestPruneIndexes(t *testing.T) {
+	old := time.Now().Add(-48 * time.Hour)
+	var deleted []string
+	fakeAPI(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodGet {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"indexes": []aiven.OpenSearchIndex{
+					{IndexName: "logs-1", CreateTime: old},
+					{IndexName: "logs-2", CreateTime: old},
+					{IndexName: "logs-3", CreateTime: old},
+					{IndexName: "logs-4", CreateTime: time.Now()},
+				},
+			})
+			return
+		}
+		if req.URL.Path == "/v1beta/project/project/service/service/index/logs-2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"errors":[{"message":"boom","status":500}],"message":"boom"}`))
+			return
+		}
+		deleted = append(deleted, req.URL.Path)
+		w.Write([]byte(`{}`))
+	})
+
+	out, err := aiven.NewWithToken("token").OpenSearch().PruneIndexes(context.Background(), aiven.OpenSearchPruneIndexesIn{
+		Project:   "project",
+		Service:   "service",
+		Pattern:   "logs-*",
+		OlderThan: 24 * time.Hour,
+	})
+	assert.NotNil(t, err)
+	assert.Len(t, out, 1, "indexes deleted before the failure are returned")
+	assert.Equal(t, "logs-1", out[0].IndexName)
+	assert.Equal(t, []string{"/v1beta/project/project/service/service/index/logs-1"}, deleted)
+}
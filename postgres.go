@@ -0,0 +1,223 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	PoolModeSession     = "session"
+	PoolModeStatement   = "statement"
+	PoolModeTransaction = "transaction"
+)
+
+// Postgres provides an api into aiven postgres
+type Postgres struct {
+	client *Client
+}
+
+// newPostgres accepts a valid aiven client and returns access to the Postgres api
+func newPostgres(client *Client) *Postgres {
+	return &Postgres{
+		client: client,
+	}
+}
+
+// PostgresDatabase represents a logical database within a postgres service
+type PostgresDatabase struct {
+	DatabaseName string `json:"database_name"`
+	LCCollate    string `json:"lc_collate,omitempty"`
+	LCCType      string `json:"lc_ctype,omitempty"`
+}
+
+type PostgresListDatabasesIn struct {
+	Project string
+	Service string
+}
+
+// ListDatabases returns the logical databases within the service
+func (p *Postgres) ListDatabases(ctx context.Context, in PostgresListDatabasesIn) ([]PostgresDatabase, error) {
+	databases, err := p.client.listDatabases(ctx, in.Project, in.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []PostgresDatabase
+	for _, db := range databases {
+		out = append(out, PostgresDatabase(db))
+	}
+	return out, nil
+}
+
+type PostgresCreateDatabaseIn struct {
+	Project   string
+	Service   string
+	Database  string
+	LCCollate string
+	LCCType   string
+}
+
+// CreateDatabase creates a logical database. Creating a database that already exists is not an error.
+func (p *Postgres) CreateDatabase(ctx context.Context, in PostgresCreateDatabaseIn) error {
+	return p.client.createDatabase(ctx, in.Project, in.Service, database{
+		DatabaseName: in.Database,
+		LCCollate:    in.LCCollate,
+		LCCType:      in.LCCType,
+	})
+}
+
+type PostgresDeleteDatabaseIn struct {
+	Project  string
+	Service  string
+	Database string
+}
+
+// DeleteDatabase removes a logical database. Deleting a database that does not exist is not an error.
+func (p *Postgres) DeleteDatabase(ctx context.Context, in PostgresDeleteDatabaseIn) error {
+	return p.client.deleteDatabase(ctx, in.Project, in.Service, in.Database)
+}
+
+// PostgresConnectionPool represents a PgBouncer connection pool
+type PostgresConnectionPool struct {
+	ConnectionURI string `json:"connection_uri,omitempty"`
+	Database      string `json:"database"`
+	PoolMode      string `json:"pool_mode"`
+	PoolName      string `json:"pool_name"`
+	PoolSize      int    `json:"pool_size"`
+	Username      string `json:"username,omitempty"`
+}
+
+type PostgresListPoolsIn struct {
+	Project string
+	Service string
+}
+
+// ListPools returns the connection pools defined for the service
+func (p *Postgres) ListPools(ctx context.Context, in PostgresListPoolsIn) ([]PostgresConnectionPool, error) {
+	service, err := p.client.Services().Get(ctx, ServiceGetIn{
+		Project: in.Project,
+		Service: in.Service,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to list connection pools for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	return service.ConnectionPools, nil
+}
+
+type PostgresCreatePoolIn struct {
+	Project  string `json:"-"`
+	Service  string `json:"-"`
+	Database string `json:"database"`
+	PoolMode string `json:"pool_mode,omitempty"`
+	PoolName string `json:"pool_name"`
+	PoolSize int    `json:"pool_size,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// CreatePool creates a PgBouncer connection pool
+func (p *Postgres) CreatePool(ctx context.Context, in PostgresCreatePoolIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/connection_pool", in.Project, in.Service)
+	out := apiErrors{}
+	if err := p.client.Post(ctx, u, in, &out); err != nil {
+		return errors.Wrapf(err, "unable to create connection pool, %v, for project:service, %v:%v", in.PoolName, in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to create connection pool, %v, for project:service, %v:%v", in.PoolName, in.Project, in.Service)
+	}
+
+	return nil
+}
+
+type PostgresUpdatePoolIn struct {
+	Project  string `json:"-"`
+	Service  string `json:"-"`
+	PoolName string `json:"-"`
+	Database string `json:"database,omitempty"`
+	PoolMode string `json:"pool_mode,omitempty"`
+	PoolSize int    `json:"pool_size,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// UpdatePool changes the database, mode, size or user of a connection pool
+func (p *Postgres) UpdatePool(ctx context.Context, in PostgresUpdatePoolIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/connection_pool/%v", in.Project, in.Service, in.PoolName)
+	out := apiErrors{}
+	if err := p.client.Put(ctx, u, in, &out); err != nil {
+		return errors.Wrapf(err, "unable to update connection pool, %v, for project:service, %v:%v", in.PoolName, in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to update connection pool, %v, for project:service, %v:%v", in.PoolName, in.Project, in.Service)
+	}
+
+	return nil
+}
+
+type PostgresDeletePoolIn struct {
+	Project  string
+	Service  string
+	PoolName string
+}
+
+// DeletePool removes a connection pool. Deleting a pool that does not exist is not an error.
+func (p *Postgres) DeletePool(ctx context.Context, in PostgresDeletePoolIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/connection_pool/%v", in.Project, in.Service, in.PoolName)
+	out := apiErrors{}
+	if err := p.client.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to delete connection pool, %v, for project:service, %v:%v", in.PoolName, in.Project, in.Service)
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to delete connection pool, %v, for project:service, %v:%v", in.PoolName, in.Project, in.Service)
+	}
+
+	return nil
+}
+
+type PostgresCreateReplicaIn struct {
+	Project     string
+	Service     string // Service to replicate
+	ReplicaName string
+	Plan        string // Plan defaults to the plan of Service
+	Cloud       string // Cloud defaults to the cloud of Service
+}
+
+// CreateReplica creates a read replica of a postgres service
+func (p *Postgres) CreateReplica(ctx context.Context, in PostgresCreateReplicaIn) (Service, error) {
+	services := p.client.Services()
+	source, err := services.Get(ctx, ServiceGetIn{
+		Project: in.Project,
+		Service: in.Service,
+	})
+	if err != nil {
+		return Service{}, errors.Wrapf(err, "unable to create replica, %v, for project:service, %v:%v", in.ReplicaName, in.Project, in.Service)
+	}
+
+	plan := in.Plan
+	if plan == "" {
+		plan = source.Plan
+	}
+	cloud := in.Cloud
+	if cloud == "" {
+		cloud = source.CloudName
+	}
+
+	return services.Create(ctx, ServiceCreateIn{
+		Project:      in.Project,
+		Cloud:        cloud,
+		Plan:         plan,
+		ProjectVPCID: source.ProjectVPCID,
+		ServiceName:  in.ReplicaName,
+		ServiceType:  source.ServiceType,
+		ServiceIntegrations: []ServiceCreateIntegration{
+			{
+				IntegrationType: IntegrationTypeReadReplica,
+				SourceService:   in.Service,
+			},
+		},
+	})
+}
@@ -0,0 +1,126 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// PostgresQueryStat holds the pg_stat_statements statistics for a single query
+type PostgresQueryStat struct {
+	BlkReadTime     float64 `json:"blk_read_time"`
+	BlkWriteTime    float64 `json:"blk_write_time"`
+	Calls           int64   `json:"calls"`
+	DatabaseName    string  `json:"database_name"`
+	MaxTime         float64 `json:"max_time"`
+	MeanTime        float64 `json:"mean_time"`
+	MinTime         float64 `json:"min_time"`
+	Query           string  `json:"query"`
+	QueryID         int64   `json:"queryid"`
+	Rows            int64   `json:"rows"`
+	SharedBlksHit   int64   `json:"shared_blks_hit"`
+	SharedBlksRead  int64   `json:"shared_blks_read"`
+	StddevTime      float64 `json:"stddev_time"`
+	TempBlksWritten int64   `json:"temp_blks_written"`
+	TotalTime       float64 `json:"total_time"`
+	UserName        string  `json:"user_name"`
+}
+
+type PostgresQueryStatsIn struct {
+	Project string `json:"-"`
+	Service string `json:"-"`
+	Limit   int    `json:"limit,omitempty"`
+	Offset  int    `json:"offset,omitempty"`
+	OrderBy string `json:"order_by,omitempty"` // OrderBy column and direction e.g. total_time:desc
+}
+
+// QueryStats returns query statistics from pg_stat_statements
+func (p *Postgres) QueryStats(ctx context.Context, in PostgresQueryStatsIn) ([]PostgresQueryStat, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/pg/query/stats", in.Project, in.Service)
+	out := struct {
+		apiErrors
+		Queries []PostgresQueryStat `json:"queries"`
+	}{}
+	if err := p.client.Post(ctx, u, in, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to retrieve query stats for project:service, %v:%v", in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to retrieve query stats for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	return out.Queries, nil
+}
+
+// PostgresCurrentQuery describes a backend from pg_stat_activity
+type PostgresCurrentQuery struct {
+	ApplicationName string     `json:"application_name"`
+	BackendStart    *time.Time `json:"backend_start"`
+	BackendType     string     `json:"backend_type"`
+	ClientAddr      string     `json:"client_addr"`
+	DatName         string     `json:"datname"`
+	PID             int        `json:"pid"`
+	Query           string     `json:"query"`
+	QueryDuration   float64    `json:"query_duration"`
+	QueryStart      *time.Time `json:"query_start"`
+	State           string     `json:"state"`
+	StateChange     *time.Time `json:"state_change"`
+	UseName         string     `json:"usename"`
+	WaitEvent       string     `json:"wait_event"`
+	WaitEventType   string     `json:"wait_event_type"`
+	XactStart       *time.Time `json:"xact_start"`
+}
+
+type PostgresCurrentQueriesIn struct {
+	Project string `json:"-"`
+	Service string `json:"-"`
+	Limit   int    `json:"limit,omitempty"`
+	Offset  int    `json:"offset,omitempty"`
+	OrderBy string `json:"order_by,omitempty"` // OrderBy column and direction e.g. query_duration:desc
+}
+
+// CurrentQueries returns the queries currently running against the service
+func (p *Postgres) CurrentQueries(ctx context.Context, in PostgresCurrentQueriesIn) ([]PostgresCurrentQuery, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/query/activity", in.Project, in.Service)
+	out := struct {
+		apiErrors
+		Queries []PostgresCurrentQuery `json:"queries"`
+	}{}
+	if err := p.client.Post(ctx, u, in, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to retrieve current queries for project:service, %v:%v", in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to retrieve current queries for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	return out.Queries, nil
+}
+
+type PostgresCancelQueryIn struct {
+	Project   string `json:"-"`
+	Service   string `json:"-"`
+	PID       int    `json:"pid"`
+	Terminate bool   `json:"terminate"` // Terminate the backend rather than cancel the running query
+}
+
+// CancelQuery cancels the query running on the backend with the specified pid, or
+// terminates the backend entirely when Terminate is set
+func (p *Postgres) CancelQuery(ctx context.Context, in PostgresCancelQueryIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/query/cancel", in.Project, in.Service)
+	out := struct {
+		apiErrors
+		Success bool `json:"success"`
+	}{}
+	if err := p.client.Post(ctx, u, in, &out); err != nil {
+		return errors.Wrapf(err, "unable to cancel query, pid %v, for project:service, %v:%v", in.PID, in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to cancel query, pid %v, for project:service, %v:%v", in.PID, in.Project, in.Service)
+	}
+	if !out.Success {
+		return errors.Errorf("unable to cancel query, pid %v, for project:service, %v:%v", in.PID, in.Project, in.Service)
+	}
+
+	return nil
+}
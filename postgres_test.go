@@ -0,0 +1,102 @@
+package aiven_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresDatabases(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+	service := os.Getenv("AIVEN_PG_SERVICE")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+	if service == "" {
+		t.Skip("AIVEN_PG_SERVICE not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	api := client.Postgres()
+	databases, err := api.ListDatabases(context.Background(), aiven.PostgresListDatabasesIn{
+		Project: project,
+		Service: service,
+	})
+	assert.Nil(t, err)
+
+	pools, err := api.ListPools(context.Background(), aiven.PostgresListPoolsIn{
+		Project: project,
+		Service: service,
+	})
+	assert.Nil(t, err)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(databases)
+	encoder.Encode(pools)
+}
+
+func TestPostgresQueryStats(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+	service := os.Getenv("AIVEN_PG_SERVICE")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+	if service == "" {
+		t.Skip("AIVEN_PG_SERVICE not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	api := client.Postgres()
+	stats, err := api.QueryStats(context.Background(), aiven.PostgresQueryStatsIn{
+		Project: project,
+		Service: service,
+		OrderBy: "total_time:desc",
+		Limit:   10,
+	})
+	assert.Nil(t, err)
+
+	queries, err := api.CurrentQueries(context.Background(), aiven.PostgresCurrentQueriesIn{
+		Project: project,
+		Service: service,
+	})
+	assert.Nil(t, err)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(stats)
+	encoder.Encode(queries)
+}
+
+func TestCancelQuery(t *testing.T) {
+	var path string
+	var body map[string]interface{}
+	fakeAPI(t, func(w http.ResponseWriter, req *http.Request) {
+		path = req.URL.Path
+		json.NewDecoder(req.Body).Decode(&body)
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true})
+	})
+
+	err := aiven.NewWithToken("token").Postgres().CancelQuery(context.Background(), aiven.PostgresCancelQueryIn{
+		Project:   "project",
+		Service:   "service",
+		PID:       1234,
+		Terminate: true,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1beta/project/project/service/service/query/cancel", path)
+	assert.Equal(t, map[string]interface{}{"pid": float64(1234), "terminate": true}, body)
+}
@@ -0,0 +1,143 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Projects provides an api into aiven projects
+type Projects struct {
+	client *Client
+}
+
+// newProjects accepts a valid aiven client and returns access to the Projects api
+func newProjects(client *Client) *Projects {
+	return &Projects{
+		client: client,
+	}
+}
+
+// ContactEmail represents an email address attached to a project
+type ContactEmail struct {
+	Email string `json:"email"`
+}
+
+// Project represents an aiven project
+type Project struct {
+	AccountID        string         `json:"account_id,omitempty"`
+	AvailableCredits string         `json:"available_credits,omitempty"`
+	BillingAddress   string         `json:"billing_address,omitempty"`
+	BillingCurrency  string         `json:"billing_currency,omitempty"`
+	BillingEmails    []ContactEmail `json:"billing_emails,omitempty"`
+	BillingExtraText string         `json:"billing_extra_text,omitempty"`
+	BillingGroupID   string         `json:"billing_group_id,omitempty"`
+	CardInfo         *CardInfo      `json:"card_info,omitempty"`
+	Country          string         `json:"country,omitempty"`
+	CountryCode      string         `json:"country_code,omitempty"`
+	DefaultCloud     string         `json:"default_cloud"`
+	EstimatedBalance string         `json:"estimated_balance,omitempty"`
+	PaymentMethod    string         `json:"payment_method,omitempty"`
+	ProjectName      string         `json:"project_name"`
+	TechEmails       []ContactEmail `json:"tech_emails,omitempty"`
+	VatID            string         `json:"vat_id,omitempty"`
+}
+
+// CardInfo holds the non-sensitive details of the card used for billing
+type CardInfo struct {
+	Brand       string `json:"brand"`
+	CardID      string `json:"card_id"`
+	Country     string `json:"country"`
+	CountryCode string `json:"country_code"`
+	ExpMonth    int    `json:"exp_month"`
+	ExpYear     int    `json:"exp_year"`
+	Last4       string `json:"last4"`
+	Name        string `json:"name"`
+}
+
+// List returns all projects visible to the authenticated user
+func (p *Projects) List(ctx context.Context) ([]Project, error) {
+	out := struct {
+		apiErrors
+		Projects []Project `json:"projects"`
+	}{}
+	if err := p.client.Get(ctx, "https://console.aiven.io/v1beta/project", &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list projects")
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list projects")
+	}
+
+	return out.Projects, nil
+}
+
+type ProjectGetIn struct {
+	Project string
+}
+
+// Get returns the details of a single project
+func (p *Projects) Get(ctx context.Context, in ProjectGetIn) (Project, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v", in.Project)
+	out := struct {
+		apiErrors
+		Project Project `json:"project"`
+	}{}
+	if err := p.client.Get(ctx, u, &out); err != nil {
+		return Project{}, errors.Wrapf(err, "unable to retrieve project, %v", in.Project)
+	}
+	if err := out.err(); err != nil {
+		return Project{}, errors.Wrapf(err, "unable to retrieve project, %v", in.Project)
+	}
+
+	return out.Project, nil
+}
+
+type ProjectCreateIn struct {
+	AccountID       string         `json:"account_id,omitempty"`
+	BillingAddress  string         `json:"billing_address,omitempty"`
+	CardID          string         `json:"card_id,omitempty"`
+	Cloud           string         `json:"cloud,omitempty"`
+	CopyFromProject string         `json:"copy_from_project,omitempty"`
+	CountryCode     string         `json:"country_code,omitempty"`
+	Project         string         `json:"project"`
+	TechEmails      []ContactEmail `json:"tech_emails,omitempty"`
+}
+
+// Create creates a new project. When CopyFromProject is set, billing and
+// contact details are copied from that project.
+func (p *Projects) Create(ctx context.Context, in ProjectCreateIn) (Project, error) {
+	out := struct {
+		apiErrors
+		Project Project `json:"project"`
+	}{}
+	if err := p.client.Post(ctx, "https://console.aiven.io/v1beta/project", in, &out); err != nil {
+		return Project{}, errors.Wrapf(err, "unable to create project, %v", in.Project)
+	}
+	if err := out.err(); err != nil {
+		return Project{}, errors.Wrapf(err, "unable to create project, %v", in.Project)
+	}
+
+	return out.Project, nil
+}
+
+type ProjectDeleteIn struct {
+	Project string
+}
+
+// Delete removes the project. Deleting a project that does not exist is not an error.
+func (p *Projects) Delete(ctx context.Context, in ProjectDeleteIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v", in.Project)
+	out := apiErrors{}
+	if err := p.client.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to delete project, %v", in.Project)
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to delete project, %v", in.Project)
+	}
+
+	return nil
+}
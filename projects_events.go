@@ -0,0 +1,145 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ProjectEvent is an entry in the audit log of a project
+type ProjectEvent struct {
+	Actor       string    `json:"actor"`
+	EventDesc   string    `json:"event_desc"`
+	EventType   string    `json:"event_type"`
+	ID          string    `json:"id"`
+	ServiceName string    `json:"service_name,omitempty"`
+	Time        time.Time `json:"time"`
+}
+
+type ProjectEventLogIn struct {
+	Project string
+
+	// Since excludes events before this time, when set
+	Since time.Time
+}
+
+// EventLog returns the audit log of the project in chronological order
+func (p *Projects) EventLog(ctx context.Context, in ProjectEventLogIn) ([]ProjectEvent, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/events", in.Project)
+	out := struct {
+		apiErrors
+		Events []ProjectEvent `json:"events"`
+	}{}
+	if err := p.client.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to retrieve event log for project, %v", in.Project)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to retrieve event log for project, %v", in.Project)
+	}
+
+	var events []ProjectEvent
+	for _, event := range out.Events {
+		if event.Time.Before(in.Since) {
+			continue
+		}
+		events = append(events, event)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+
+	return events, nil
+}
+
+type ProjectFollowEventLogIn struct {
+	Project string
+
+	// Since excludes events before this time, when set
+	Since time.Time
+
+	// Interval between polls for new events; defaults to 30s
+	Interval time.Duration
+
+	// Callback is invoked for each event in chronological order
+	Callback func(ProjectEvent)
+
+	// OnError, if set, is invoked with each failed poll before it is retried
+	OnError func(error)
+}
+
+// EventCursor tracks the events already emitted while following an event log. Aiven
+// timestamps have limited precision so the events of the newest timestamp are returned
+// again by the next poll and are recognised by ID.
+type EventCursor struct {
+	// Since excludes events before this time; it advances to the newest event seen
+	Since time.Time
+
+	seen map[string]bool
+}
+
+// Next returns the events, in chronological order, not already returned by Next
+func (c *EventCursor) Next(events []ProjectEvent) []ProjectEvent {
+	if c.seen == nil {
+		c.seen = map[string]bool{}
+	}
+
+	var unseen []ProjectEvent
+	for _, event := range events {
+		if event.Time.Before(c.Since) || c.seen[event.ID] {
+			continue
+		}
+		if event.Time.After(c.Since) {
+			c.Since = event.Time
+			c.seen = map[string]bool{}
+		}
+		c.seen[event.ID] = true
+		unseen = append(unseen, event)
+	}
+	return unseen
+}
+
+// FollowEventLog emits the events since in.Since and then polls for new events until
+// ctx is done, at which point ctx.Err() is returned. Failed polls are retried with
+// exponential backoff, up to 5 minutes apart, unless aiven rejects the credentials.
+func (p *Projects) FollowEventLog(ctx context.Context, in ProjectFollowEventLogIn) error {
+	interval := in.Interval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	cursor := EventCursor{Since: in.Since}
+	delay := interval
+	for {
+		events, err := p.EventLog(ctx, ProjectEventLogIn{
+			Project: in.Project,
+			Since:   cursor.Since,
+		})
+		switch {
+		case err != nil && ctx.Err() != nil:
+			return ctx.Err()
+		case IsUnauthorized(err):
+			return err
+		case err != nil:
+			if in.OnError != nil {
+				in.OnError(err)
+			}
+			if delay *= 2; delay > 5*time.Minute {
+				delay = 5 * time.Minute
+			}
+		default:
+			for _, event := range cursor.Next(events) {
+				in.Callback(event)
+			}
+			delay = interval
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
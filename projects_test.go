@@ -0,0 +1,178 @@
+package aiven_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjects(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	api := client.Projects()
+	projects, err := api.List(context.Background())
+	assert.Nil(t, err)
+	assert.NotEmpty(t, projects)
+
+	out, err := api.Get(context.Background(), aiven.ProjectGetIn{
+		Project: project,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, project, out.ProjectName)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(out)
+}
+
+func TestProjectEventLog(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	events, err := client.Projects().EventLog(context.Background(), aiven.ProjectEventLogIn{
+		Project: project,
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, events)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(events)
+}
+
+func TestEventCursor(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	event := func(id string, offset time.Duration) aiven.ProjectEvent {
+		return aiven.ProjectEvent{ID: id, Time: at.Add(offset)}
+	}
+
+	cursor := aiven.EventCursor{Since: at}
+
+	// events before since are excluded
+	got := cursor.Next([]aiven.ProjectEvent{
+		event("old", -time.Minute),
+		event("a", 0),
+		event("b", time.Second),
+	})
+	assert.Equal(t, []aiven.ProjectEvent{event("a", 0), event("b", time.Second)}, got)
+	assert.Equal(t, at.Add(time.Second), cursor.Since)
+
+	// the next poll repeats the events sharing the newest timestamp; only c is new
+	got = cursor.Next([]aiven.ProjectEvent{
+		event("b", time.Second),
+		event("c", time.Second),
+	})
+	assert.Equal(t, []aiven.ProjectEvent{event("c", time.Second)}, got)
+
+	// equal timestamps again across polls, nothing new
+	got = cursor.Next([]aiven.ProjectEvent{
+		event("b", time.Second),
+		event("c", time.Second),
+	})
+	assert.Len(t, got, 0)
+
+	// the timestamp advances; ids seen at earlier timestamps no longer matter
+	got = cursor.Next([]aiven.ProjectEvent{
+		event("c", time.Second),
+		event("d", 2*time.Second),
+		event("e", 2*time.Second),
+	})
+	assert.Equal(t, []aiven.ProjectEvent{event("d", 2*time.Second), event("e", 2*time.Second)}, got)
+	assert.Equal(t, at.Add(2*time.Second), cursor.Since)
+}
+
+func TestFollowEventLog(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	first := aiven.ProjectEvent{ID: "1", EventType: "service_create", Time: at}
+	second := aiven.ProjectEvent{ID: "2", EventType: "service_delete", Time: at.Add(time.Second)}
+
+	t.Run("retries failed polls", func(t *testing.T) {
+		responses := []struct {
+			Status int
+			Events []aiven.ProjectEvent
+		}{
+			{Status: http.StatusBadGateway},
+			{Status: http.StatusOK, Events: []aiven.ProjectEvent{first}},
+			{Status: http.StatusServiceUnavailable},
+			{Status: http.StatusOK, Events: []aiven.ProjectEvent{first, second}},
+		}
+		var polls int
+		fakeAPI(t, func(w http.ResponseWriter, req *http.Request) {
+			resp := responses[len(responses)-1]
+			if polls < len(responses) {
+				resp = responses[polls]
+			}
+			polls++
+			w.WriteHeader(resp.Status)
+			if resp.Status != http.StatusOK {
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"errors":  []aiven.Error{{Message: "unavailable", Status: resp.Status}},
+					"message": "unavailable",
+				})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"events": resp.Events})
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var events []aiven.ProjectEvent
+		var failures int
+		err := aiven.NewWithToken("token").Projects().FollowEventLog(ctx, aiven.ProjectFollowEventLogIn{
+			Project:  "project",
+			Since:    at.Add(-time.Hour),
+			Interval: time.Millisecond,
+			Callback: func(event aiven.ProjectEvent) {
+				if events = append(events, event); len(events) == 2 {
+					cancel()
+				}
+			},
+			OnError: func(err error) {
+				failures++
+			},
+		})
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, []aiven.ProjectEvent{first, second}, events)
+		assert.Equal(t, 2, failures)
+		assert.Equal(t, 4, polls)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		var polls int
+		fakeAPI(t, func(w http.ResponseWriter, req *http.Request) {
+			polls++
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":[{"message":"invalid token","status":403}],"message":"invalid token"}`))
+		})
+
+		err := aiven.NewWithToken("token").Projects().FollowEventLog(context.Background(), aiven.ProjectFollowEventLogIn{
+			Project:  "project",
+			Interval: time.Millisecond,
+			Callback: func(aiven.ProjectEvent) {},
+		})
+		assert.True(t, aiven.IsUnauthorized(err))
+		assert.Equal(t, 1, polls)
+	})
+}
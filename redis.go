@@ -0,0 +1,136 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Redis provides an api into aiven redis and valkey
+type Redis struct {
+	client *Client
+}
+
+// newRedis accepts a valid aiven client and returns access to the Redis api
+func newRedis(client *Client) *Redis {
+	return &Redis{
+		client: client,
+	}
+}
+
+type RedisListUsersIn struct {
+	Project string
+	Service string
+}
+
+// ListUsers returns the users of the service along with their ACLs
+func (r *Redis) ListUsers(ctx context.Context, in RedisListUsersIn) ([]ServiceUser, error) {
+	service, err := r.client.Services().Get(ctx, ServiceGetIn{
+		Project: in.Project,
+		Service: in.Service,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to list users for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	return service.Users, nil
+}
+
+type RedisCreateUserIn struct {
+	Project       string                    `json:"-"`
+	Service       string                    `json:"-"`
+	Username      string                    `json:"username"`
+	AccessControl *ServiceUserAccessControl `json:"access_control,omitempty"`
+}
+
+// CreateUser creates a user with the specified ACL. The returned user includes the
+// generated password.
+func (r *Redis) CreateUser(ctx context.Context, in RedisCreateUserIn) (ServiceUser, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/user", in.Project, in.Service)
+	if in.AccessControl != nil {
+		acl := emptyLists(*in.AccessControl)
+		in.AccessControl = &acl
+	}
+	out := struct {
+		apiErrors
+		User ServiceUser `json:"user"`
+	}{}
+	if err := r.client.Post(ctx, u, in, &out); err != nil {
+		return ServiceUser{}, errors.Wrapf(err, "unable to create user, %v, for project:service, %v:%v", in.Username, in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return ServiceUser{}, errors.Wrapf(err, "unable to create user, %v, for project:service, %v:%v", in.Username, in.Project, in.Service)
+	}
+
+	return out.User, nil
+}
+
+type RedisUpdateUserIn struct {
+	Project       string
+	Service       string
+	Username      string
+	AccessControl ServiceUserAccessControl
+}
+
+// UpdateUser replaces the ACL of an existing user. Nil lists are sent empty, which
+// clears them.
+func (r *Redis) UpdateUser(ctx context.Context, in RedisUpdateUserIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/user/%v", in.Project, in.Service, in.Username)
+	body := struct {
+		Operation     string                   `json:"operation"`
+		AccessControl ServiceUserAccessControl `json:"access_control"`
+	}{
+		Operation:     "set-access-control",
+		AccessControl: emptyLists(in.AccessControl),
+	}
+	out := apiErrors{}
+	if err := r.client.Put(ctx, u, body, &out); err != nil {
+		return errors.Wrapf(err, "unable to update user, %v, for project:service, %v:%v", in.Username, in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to update user, %v, for project:service, %v:%v", in.Username, in.Project, in.Service)
+	}
+
+	return nil
+}
+
+type RedisDeleteUserIn struct {
+	Project  string
+	Service  string
+	Username string
+}
+
+// DeleteUser removes a user. Deleting a user that does not exist is not an error.
+func (r *Redis) DeleteUser(ctx context.Context, in RedisDeleteUserIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/user/%v", in.Project, in.Service, in.Username)
+	out := apiErrors{}
+	if err := r.client.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to delete user, %v, for project:service, %v:%v", in.Username, in.Project, in.Service)
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to delete user, %v, for project:service, %v:%v", in.Username, in.Project, in.Service)
+	}
+
+	return nil
+}
+
+// emptyLists returns acl with its nil lists replaced by empty ones, so they encode as
+// [] rather than null
+func emptyLists(acl ServiceUserAccessControl) ServiceUserAccessControl {
+	nonNil := func(v []string) []string {
+		if v == nil {
+			return []string{}
+		}
+		return v
+	}
+	return ServiceUserAccessControl{
+		RedisACLCategories: nonNil(acl.RedisACLCategories),
+		RedisACLChannels:   nonNil(acl.RedisACLChannels),
+		RedisACLCommands:   nonNil(acl.RedisACLCommands),
+		RedisACLKeys:       nonNil(acl.RedisACLKeys),
+	}
+}
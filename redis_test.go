@@ -0,0 +1,63 @@
+package aiven_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisUsers(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+	service := os.Getenv("AIVEN_REDIS_SERVICE")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+	if service == "" {
+		t.Skip("AIVEN_REDIS_SERVICE not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	out, err := client.Redis().ListUsers(context.Background(), aiven.RedisListUsersIn{
+		Project: project,
+		Service: service,
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, out)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(out)
+}
+
+func TestRedisUpdateUser(t *testing.T) {
+	var body map[string]interface{}
+	fakeAPI(t, func(w http.ResponseWriter, req *http.Request) {
+		json.NewDecoder(req.Body).Decode(&body)
+		w.Write([]byte(`{}`))
+	})
+
+	err := aiven.NewWithToken("token").Redis().UpdateUser(context.Background(), aiven.RedisUpdateUserIn{
+		Project:  "project",
+		Service:  "service",
+		Username: "app",
+		AccessControl: aiven.ServiceUserAccessControl{
+			RedisACLKeys: []string{"app:*"},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"redis_acl_categories": []interface{}{},
+		"redis_acl_channels":   []interface{}{},
+		"redis_acl_commands":   []interface{}{},
+		"redis_acl_keys":       []interface{}{"app:*"},
+	}, body["access_control"], "lists not given are cleared")
+}
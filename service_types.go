@@ -0,0 +1,95 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// HoursPerMonth is the number of hours aiven bills as a full month
+	HoursPerMonth = 730
+)
+
+// ServiceBackupConfig describes the backups taken for a service plan
+type ServiceBackupConfig struct {
+	Interval     int    `json:"interval"`
+	MaxCount     int    `json:"max_count"`
+	RecoveryMode string `json:"recovery_mode"`
+}
+
+// ServicePlanRegion holds the per-cloud specifics of a service plan
+type ServicePlanRegion struct {
+	DiskSpaceMB  int     `json:"disk_space_mb"`
+	NodeMemoryMB float64 `json:"node_memory_mb"`
+	PriceUSD     string  `json:"price_usd"`
+}
+
+// ServicePlan describes a plan that a service may be created with
+type ServicePlan struct {
+	BackupConfig     ServiceBackupConfig          `json:"backup_config"`
+	DiskSpaceMB      int                          `json:"disk_space_mb"`
+	MaxMemoryPercent int                          `json:"max_memory_percent,omitempty"`
+	NodeCount        int                          `json:"node_count"`
+	Regions          map[string]ServicePlanRegion `json:"regions"`
+	ServicePlan      string                       `json:"service_plan"`
+	ServiceType      string                       `json:"service_type"`
+}
+
+// PlanPrice holds the price of a service plan in a specific cloud
+type PlanPrice struct {
+	HourlyUSD  float64 `json:"hourly_usd"`
+	MonthlyUSD float64 `json:"monthly_usd"`
+}
+
+// Price returns the price of the plan in the specified cloud. ok is false if the
+// plan is not available in the cloud.
+func (p ServicePlan) Price(cloud string) (price PlanPrice, ok bool) {
+	region, ok := p.Regions[cloud]
+	if !ok {
+		return PlanPrice{}, false
+	}
+
+	hourly, err := strconv.ParseFloat(region.PriceUSD, 64)
+	if err != nil {
+		return PlanPrice{}, false
+	}
+
+	return PlanPrice{
+		HourlyUSD:  hourly,
+		MonthlyUSD: hourly * HoursPerMonth,
+	}, true
+}
+
+// ServiceType describes a type of service e.g. kafka along with its available plans
+type ServiceType struct {
+	Description            string                 `json:"description"`
+	LatestAvailableVersion string                 `json:"latest_available_version,omitempty"`
+	ServicePlans           []ServicePlan          `json:"service_plans"`
+	UserConfigSchema       map[string]interface{} `json:"user_config_schema"`
+}
+
+type ServiceTypesIn struct {
+	Project string
+}
+
+// ServiceTypes returns the service types available to the project keyed by type name
+//
+// See https://api.aiven.io/doc/#tag/Service/operation/ListProjectServiceTypes
+func (c *Client) ServiceTypes(ctx context.Context, in ServiceTypesIn) (map[string]ServiceType, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service_types", in.Project)
+	out := struct {
+		apiErrors
+		ServiceTypes map[string]ServiceType `json:"service_types"`
+	}{}
+	if err := c.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to retrieve service types for project, %v", in.Project)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to retrieve service types for project, %v", in.Project)
+	}
+
+	return out.ServiceTypes, nil
+}
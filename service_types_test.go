@@ -0,0 +1,53 @@
+package aiven_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceTypes(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	out, err := client.ServiceTypes(context.Background(), aiven.ServiceTypesIn{
+		Project: project,
+	})
+	assert.Nil(t, err)
+	assert.Contains(t, out, "kafka")
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(out["kafka"].ServicePlans)
+}
+
+func TestServicePlanPrice(t *testing.T) {
+	plan := aiven.ServicePlan{
+		Regions: map[string]aiven.ServicePlanRegion{
+			"google-europe-west1": {PriceUSD: "0.5000"},
+			"aws-broken":          {PriceUSD: "n/a"},
+		},
+	}
+
+	price, ok := plan.Price("google-europe-west1")
+	assert.True(t, ok)
+	assert.Equal(t, 0.5, price.HourlyUSD)
+	assert.Equal(t, 365.0, price.MonthlyUSD)
+
+	_, ok = plan.Price("aws-broken")
+	assert.False(t, ok)
+
+	_, ok = plan.Price("azure-unknown")
+	assert.False(t, ok)
+}
@@ -0,0 +1,255 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ServiceStatePowerOff    = "POWEROFF"
+	ServiceStateRebalancing = "REBALANCING"
+	ServiceStateRebuilding  = "REBUILDING"
+	ServiceStateRunning     = "RUNNING"
+)
+
+// Services provides an api into the lifecycle of aiven services
+type Services struct {
+	client *Client
+}
+
+// newServices accepts a valid aiven client and returns access to the Services api
+func newServices(client *Client) *Services {
+	return &Services{
+		client: client,
+	}
+}
+
+// ServiceComponent describes an endpoint exposed by a service e.g. kafka, schema_registry
+type ServiceComponent struct {
+	Component                 string `json:"component"`
+	Host                      string `json:"host"`
+	KafkaAuthenticationMethod string `json:"kafka_authentication_method,omitempty"`
+	Port                      int    `json:"port"`
+	Route                     string `json:"route"`
+	SSL                       bool   `json:"ssl"`
+	Usage                     string `json:"usage"`
+}
+
+// ServiceProgressUpdate describes the progress of a single phase of a node rebuild
+type ServiceProgressUpdate struct {
+	Completed bool   `json:"completed"`
+	Current   int64  `json:"current"`
+	Max       int64  `json:"max"`
+	Min       int64  `json:"min"`
+	Phase     string `json:"phase"`
+	Unit      string `json:"unit"`
+}
+
+// ServiceNodeState describes the state of a single node of a service
+type ServiceNodeState struct {
+	Name            string                  `json:"name"`
+	ProgressUpdates []ServiceProgressUpdate `json:"progress_updates,omitempty"`
+	Role            string                  `json:"role,omitempty"`
+	State           string                  `json:"state"`
+}
+
+// ServiceUserAccessControl holds the redis ACL of a service user. Patterns follow
+// redis ACL syntax e.g. keys app:*, commands +get, categories -@dangerous
+type ServiceUserAccessControl struct {
+	RedisACLCategories []string `json:"redis_acl_categories"`
+	RedisACLChannels   []string `json:"redis_acl_channels"`
+	RedisACLCommands   []string `json:"redis_acl_commands"`
+	RedisACLKeys       []string `json:"redis_acl_keys"`
+}
+
+// ServiceUser represents a user of the service
+type ServiceUser struct {
+	AccessControl *ServiceUserAccessControl `json:"access_control,omitempty"`
+	Password      string                    `json:"password,omitempty"`
+	Type          string                    `json:"type"`
+	Username      string                    `json:"username"`
+}
+
+// ServiceMaintenanceUpdate describes an update pending for the next maintenance window
+type ServiceMaintenanceUpdate struct {
+	Deadline    *time.Time `json:"deadline,omitempty"`
+	Description string     `json:"description"`
+	StartAfter  *time.Time `json:"start_after,omitempty"`
+	StartAt     *time.Time `json:"start_at,omitempty"`
+}
+
+// ServiceMaintenance describes the maintenance window of a service and any pending updates
+type ServiceMaintenance struct {
+	DOW     string                     `json:"dow,omitempty"`
+	Time    string                     `json:"time,omitempty"`
+	Updates []ServiceMaintenanceUpdate `json:"updates,omitempty"`
+}
+
+// Service represents an aiven service
+type Service struct {
+	CloudDescription      string                   `json:"cloud_description"`
+	CloudName             string                   `json:"cloud_name"`
+	Components            []ServiceComponent       `json:"components"`
+	ConnectionInfo        map[string]interface{}   `json:"connection_info"`
+	ConnectionPools       []PostgresConnectionPool `json:"connection_pools,omitempty"`
+	CreateTime            time.Time                `json:"create_time"`
+	Databases             []string                 `json:"databases,omitempty"`
+	DiskSpaceMB           int                      `json:"disk_space_mb"`
+	GroupList             []string                 `json:"group_list"`
+	Maintenance           *ServiceMaintenance      `json:"maintenance"`
+	NodeCount             int                      `json:"node_count"`
+	NodeCPUCount          int                      `json:"node_cpu_count"`
+	NodeMemoryMB          float64                  `json:"node_memory_mb"`
+	NodeStates            []ServiceNodeState       `json:"node_states"`
+	Plan                  string                   `json:"plan"`
+	ProjectVPCID          string                   `json:"project_vpc_id"`
+	ServiceName           string                   `json:"service_name"`
+	ServiceType           string                   `json:"service_type"`
+	ServiceTypeDesc       string                   `json:"service_type_description"`
+	ServiceURI            string                   `json:"service_uri"`
+	ServiceURIParams      map[string]string        `json:"service_uri_params"`
+	State                 string                   `json:"state"`
+	TerminationProtection bool                     `json:"termination_protection"`
+	Topics                []KafkaTopic             `json:"topics,omitempty"`
+	UpdateTime            time.Time                `json:"update_time"`
+	UserConfig            map[string]interface{}   `json:"user_config"`
+	Users                 []ServiceUser            `json:"users,omitempty"`
+}
+
+type ServiceListIn struct {
+	Project string
+}
+
+// List returns all services within the project
+func (s *Services) List(ctx context.Context, in ServiceListIn) ([]Service, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service", in.Project)
+	out := struct {
+		apiErrors
+		Services []Service `json:"services"`
+	}{}
+	if err := s.client.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list services for project, %v", in.Project)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list services for project, %v", in.Project)
+	}
+
+	return out.Services, nil
+}
+
+type ServiceGetIn struct {
+	Project string
+	Service string
+}
+
+// Get returns the full details of a service
+func (s *Services) Get(ctx context.Context, in ServiceGetIn) (Service, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v", in.Project, in.Service)
+	out := struct {
+		apiErrors
+		Service Service `json:"service"`
+	}{}
+	if err := s.client.Get(ctx, u, &out); err != nil {
+		return Service{}, errors.Wrapf(err, "unable to retrieve service for project:service, %v:%v", in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return Service{}, errors.Wrapf(err, "unable to retrieve service for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	return out.Service, nil
+}
+
+// ServiceCreateIntegration describes an integration to establish as the service is created
+type ServiceCreateIntegration struct {
+	DestService     string                 `json:"dest_service,omitempty"`
+	IntegrationType IntegrationType        `json:"integration_type"`
+	SourceService   string                 `json:"source_service,omitempty"`
+	UserConfig      map[string]interface{} `json:"user_config,omitempty"`
+}
+
+type ServiceCreateIn struct {
+	Project               string                     `json:"-"`
+	Cloud                 string                     `json:"cloud,omitempty"`
+	GroupName             string                     `json:"group_name,omitempty"`
+	Maintenance           *ServiceMaintenance        `json:"maintenance,omitempty"`
+	Plan                  string                     `json:"plan"`
+	ProjectVPCID          string                     `json:"project_vpc_id,omitempty"`
+	ServiceIntegrations   []ServiceCreateIntegration `json:"service_integrations,omitempty"`
+	ServiceName           string                     `json:"service_name"`
+	ServiceType           string                     `json:"service_type"`
+	TerminationProtection bool                       `json:"termination_protection,omitempty"`
+	UserConfig            map[string]interface{}     `json:"user_config,omitempty"`
+}
+
+// Create creates a new service. The service is returned while still being built;
+// see State for progress.
+func (s *Services) Create(ctx context.Context, in ServiceCreateIn) (Service, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service", in.Project)
+	out := struct {
+		apiErrors
+		Service Service `json:"service"`
+	}{}
+	if err := s.client.Post(ctx, u, in, &out); err != nil {
+		return Service{}, errors.Wrapf(err, "unable to create service for project:service, %v:%v", in.Project, in.ServiceName)
+	}
+	if err := out.err(); err != nil {
+		return Service{}, errors.Wrapf(err, "unable to create service for project:service, %v:%v", in.Project, in.ServiceName)
+	}
+
+	return out.Service, nil
+}
+
+// ServiceUpdateIn describes changes to a service. Nil or empty fields are left unchanged.
+type ServiceUpdateIn struct {
+	Project               string                 `json:"-"`
+	Service               string                 `json:"-"`
+	Cloud                 string                 `json:"cloud,omitempty"`
+	Maintenance           *ServiceMaintenance    `json:"maintenance,omitempty"`
+	Plan                  string                 `json:"plan,omitempty"`
+	Powered               *bool                  `json:"powered,omitempty"`
+	TerminationProtection *bool                  `json:"termination_protection,omitempty"`
+	UserConfig            map[string]interface{} `json:"user_config,omitempty"`
+}
+
+// Update changes the plan, power state, maintenance window, termination protection
+// or user config of a service
+func (s *Services) Update(ctx context.Context, in ServiceUpdateIn) (Service, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v", in.Project, in.Service)
+	out := struct {
+		apiErrors
+		Service Service `json:"service"`
+	}{}
+	if err := s.client.Put(ctx, u, in, &out); err != nil {
+		return Service{}, errors.Wrapf(err, "unable to update service for project:service, %v:%v", in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return Service{}, errors.Wrapf(err, "unable to update service for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	return out.Service, nil
+}
+
+type ServiceDeleteIn struct {
+	Project string
+	Service string
+}
+
+// Delete terminates the service. Deleting a service that does not exist is not an error.
+func (s *Services) Delete(ctx context.Context, in ServiceDeleteIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v", in.Project, in.Service)
+	out := apiErrors{}
+	if err := s.client.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to delete service for project:service, %v:%v", in.Project, in.Service)
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to delete service for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	return nil
+}
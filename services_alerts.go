@@ -0,0 +1,42 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ServiceAlert is an active alert raised by aiven for a service
+type ServiceAlert struct {
+	CreateTime  time.Time `json:"create_time"`
+	Event       string    `json:"event"`
+	NodeName    string    `json:"node_name,omitempty"`
+	ProjectName string    `json:"project_name"`
+	ServiceName string    `json:"service_name"`
+	ServiceType string    `json:"service_type"`
+	Severity    string    `json:"severity"`
+}
+
+type ServiceAlertsIn struct {
+	Project string
+	Service string
+}
+
+// Alerts returns the active alerts of the service
+func (s *Services) Alerts(ctx context.Context, in ServiceAlertsIn) ([]ServiceAlert, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/alerts", in.Project, in.Service)
+	out := struct {
+		apiErrors
+		Alerts []ServiceAlert `json:"alerts"`
+	}{}
+	if err := s.client.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to retrieve alerts for project:service, %v:%v", in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to retrieve alerts for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	return out.Alerts, nil
+}
@@ -0,0 +1,99 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ServiceBackup describes a backup of a service
+type ServiceBackup struct {
+	BackupName      string    `json:"backup_name"`
+	BackupTime      time.Time `json:"backup_time"`
+	DataSize        int64     `json:"data_size"`
+	StorageLocation string    `json:"storage_location,omitempty"`
+}
+
+type ServiceBackupsIn struct {
+	Project string
+	Service string
+}
+
+// Backups returns the backups available for the service
+func (s *Services) Backups(ctx context.Context, in ServiceBackupsIn) ([]ServiceBackup, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/backups", in.Project, in.Service)
+	out := struct {
+		apiErrors
+		Backups []ServiceBackup `json:"backups"`
+	}{}
+	if err := s.client.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list backups for project:service, %v:%v", in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list backups for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	return out.Backups, nil
+}
+
+type ServiceForkIn struct {
+	Project     string
+	Service     string // Service to fork from
+	ServiceName string // ServiceName of the new service
+
+	// RecoveryTargetTime restores to a point in time, where supported e.g. postgres.
+	// It may not be combined with BackupName.
+	RecoveryTargetTime *time.Time
+
+	// BackupName restores a specific backup rather than the latest
+	BackupName string
+
+	Plan  string // Plan defaults to the plan of Service
+	Cloud string // Cloud defaults to the cloud of Service
+}
+
+// Fork creates a new service from a backup of an existing service
+func (s *Services) Fork(ctx context.Context, in ServiceForkIn) (Service, error) {
+	if in.RecoveryTargetTime != nil && in.BackupName != "" {
+		return Service{}, errors.Errorf("unable to fork service, %v: recovery target time and backup name may not both be set", in.ServiceName)
+	}
+
+	source, err := s.Get(ctx, ServiceGetIn{
+		Project: in.Project,
+		Service: in.Service,
+	})
+	if err != nil {
+		return Service{}, errors.Wrapf(err, "unable to fork service, %v, from project:service, %v:%v", in.ServiceName, in.Project, in.Service)
+	}
+
+	plan := in.Plan
+	if plan == "" {
+		plan = source.Plan
+	}
+	cloud := in.Cloud
+	if cloud == "" {
+		cloud = source.CloudName
+	}
+
+	userConfig := map[string]interface{}{
+		"service_to_fork_from": in.Service,
+	}
+	if in.RecoveryTargetTime != nil {
+		userConfig["recovery_target_time"] = in.RecoveryTargetTime.UTC().Format(time.RFC3339)
+	}
+	if in.BackupName != "" {
+		userConfig["recovery_basebackup_name"] = in.BackupName
+	}
+
+	return s.Create(ctx, ServiceCreateIn{
+		Project:      in.Project,
+		Cloud:        cloud,
+		Plan:         plan,
+		ProjectVPCID: source.ProjectVPCID,
+		ServiceName:  in.ServiceName,
+		ServiceType:  source.ServiceType,
+		UserConfig:   userConfig,
+	})
+}
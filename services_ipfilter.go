@@ -0,0 +1,169 @@
+package aiven
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// ErrIPFilterEmpty is returned by RemoveIPFilter when removing the networks would leave
+// no network allowed to connect to the service
+var ErrIPFilterEmpty = errors.New("removing the networks would leave the ip filter empty and block all access to the service")
+
+// IPFilterEntry allows connections to a service from the addresses within Network
+type IPFilterEntry struct {
+	Description string `json:"description,omitempty"`
+	Network     string `json:"network"`
+}
+
+// ipFilter returns the raw ip_filter of the user config; aiven accepts entries either as a
+// plain cidr string or as an object holding network and description
+func ipFilter(userConfig map[string]interface{}) []interface{} {
+	v, _ := userConfig["ip_filter"].([]interface{})
+	return v
+}
+
+// ipFilterNetwork returns the cidr of a raw ip_filter entry
+func ipFilterNetwork(v interface{}) string {
+	switch entry := v.(type) {
+	case string:
+		return entry
+	case map[string]interface{}:
+		network, _ := entry["network"].(string)
+		return network
+	default:
+		return ""
+	}
+}
+
+func ipFilterEntries(raw []interface{}) []IPFilterEntry {
+	entries := make([]IPFilterEntry, 0, len(raw))
+	for _, v := range raw {
+		entry := IPFilterEntry{Network: ipFilterNetwork(v)}
+		if m, ok := v.(map[string]interface{}); ok {
+			entry.Description, _ = m["description"].(string)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+type ServiceIPFilterIn struct {
+	Project string
+	Service string
+}
+
+// IPFilter returns the networks allowed to connect to the service
+func (s *Services) IPFilter(ctx context.Context, in ServiceIPFilterIn) ([]IPFilterEntry, error) {
+	service, err := s.Get(ctx, ServiceGetIn{
+		Project: in.Project,
+		Service: in.Service,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to retrieve ip filter for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	return ipFilterEntries(ipFilter(service.UserConfig)), nil
+}
+
+// updateIPFilter replaces the ip_filter of the service. Aiven merges user_config
+// updates key by key, so the remainder of the user config is left as is.
+func (s *Services) updateIPFilter(ctx context.Context, project, service string, raw []interface{}) ([]IPFilterEntry, error) {
+	if raw == nil {
+		raw = []interface{}{}
+	}
+
+	updated, err := s.Update(ctx, ServiceUpdateIn{
+		Project: project,
+		Service: service,
+		UserConfig: map[string]interface{}{
+			"ip_filter": raw,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to update ip filter for project:service, %v:%v", project, service)
+	}
+
+	return ipFilterEntries(ipFilter(updated.UserConfig)), nil
+}
+
+type ServiceAddIPFilterIn struct {
+	Project string
+	Service string
+	Entries []IPFilterEntry
+}
+
+// AddIPFilter allows the specified networks to connect to the service. Networks already
+// present are left unchanged.
+func (s *Services) AddIPFilter(ctx context.Context, in ServiceAddIPFilterIn) ([]IPFilterEntry, error) {
+	service, err := s.Get(ctx, ServiceGetIn{
+		Project: in.Project,
+		Service: in.Service,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to update ip filter for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	raw := ipFilter(service.UserConfig)
+	existing := map[string]bool{}
+	for _, v := range raw {
+		existing[ipFilterNetwork(v)] = true
+	}
+
+	for _, entry := range in.Entries {
+		if existing[entry.Network] {
+			continue
+		}
+		existing[entry.Network] = true
+
+		if entry.Description == "" {
+			raw = append(raw, entry.Network)
+		} else {
+			raw = append(raw, map[string]interface{}{
+				"network":     entry.Network,
+				"description": entry.Description,
+			})
+		}
+	}
+
+	return s.updateIPFilter(ctx, in.Project, in.Service, raw)
+}
+
+type ServiceRemoveIPFilterIn struct {
+	Project  string
+	Service  string
+	Networks []string
+
+	// Force allows the last networks to be removed, which blocks all access to the service
+	Force bool
+}
+
+// RemoveIPFilter stops allowing the specified networks to connect to the service.
+// ErrIPFilterEmpty is returned rather than removing the last networks unless in.Force
+// is set.
+func (s *Services) RemoveIPFilter(ctx context.Context, in ServiceRemoveIPFilterIn) ([]IPFilterEntry, error) {
+	service, err := s.Get(ctx, ServiceGetIn{
+		Project: in.Project,
+		Service: in.Service,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to update ip filter for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	remove := map[string]bool{}
+	for _, network := range in.Networks {
+		remove[network] = true
+	}
+
+	var raw []interface{}
+	for _, v := range ipFilter(service.UserConfig) {
+		if !remove[ipFilterNetwork(v)] {
+			raw = append(raw, v)
+		}
+	}
+	if len(raw) == 0 && !in.Force {
+		return nil, ErrIPFilterEmpty
+	}
+
+	return s.updateIPFilter(ctx, in.Project, in.Service, raw)
+}
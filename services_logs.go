@@ -0,0 +1,163 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// ServiceLogEntry represents a single log line from a service node
+type ServiceLogEntry struct {
+	Hostname string    `json:"hostname,omitempty"`
+	Msg      string    `json:"msg"`
+	Time     time.Time `json:"time"`
+	Unit     string    `json:"unit,omitempty"`
+}
+
+type ServiceLogsIn struct {
+	Project   string `json:"-"`
+	Service   string `json:"-"`
+	Limit     int    `json:"limit,omitempty"`
+	Offset    string `json:"offset,omitempty"`     // Offset returned by a prior call; empty starts at the beginning or end per SortOrder
+	SortOrder string `json:"sort_order,omitempty"` // SortOrder is either asc or desc
+}
+
+type ServiceLogsOut struct {
+	FirstLogOffset string            `json:"first_log_offset"`
+	Logs           []ServiceLogEntry `json:"logs"`
+	Offset         string            `json:"offset"`
+}
+
+// Logs returns a page of log entries from the service
+func (s *Services) Logs(ctx context.Context, in ServiceLogsIn) (ServiceLogsOut, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/logs", in.Project, in.Service)
+	out := struct {
+		apiErrors
+		ServiceLogsOut
+	}{}
+	if err := s.client.Post(ctx, u, in, &out); err != nil {
+		return ServiceLogsOut{}, errors.Wrapf(err, "unable to retrieve logs for project:service, %v:%v", in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return ServiceLogsOut{}, errors.Wrapf(err, "unable to retrieve logs for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	return out.ServiceLogsOut, nil
+}
+
+type ServiceFollowLogsIn struct {
+	Project string
+	Service string
+
+	// Limit is the number of recent entries to emit before following; defaults to 100
+	Limit int
+
+	// Interval between polls for new entries; defaults to 5s
+	Interval time.Duration
+
+	// Callback is invoked for each log entry in chronological order
+	Callback func(ServiceLogEntry)
+}
+
+// LogCursor tracks the log entries already emitted while following the logs of a service
+// so that entries returned again by a later poll, e.g. at the offset boundary, are not
+// emitted twice
+type LogCursor struct {
+	last time.Time
+	seen map[string]int
+}
+
+// Next returns the entries, in chronological order, that are newer than those already
+// passed to Next. Entries sharing the timestamp of the newest entry seen so far are only
+// returned if they occur more often than they did in earlier calls, so identical lines
+// logged within the same timestamp are each returned once.
+func (c *LogCursor) Next(entries []ServiceLogEntry) []ServiceLogEntry {
+	var unseen []ServiceLogEntry
+	counts := map[string]int{}
+	for _, entry := range entries {
+		if entry.Time.Before(c.last) {
+			continue
+		}
+		if entry.Time.After(c.last) || c.seen == nil {
+			c.last = entry.Time
+			c.seen = map[string]int{}
+			counts = map[string]int{}
+		}
+
+		key := entry.Hostname + "\x00" + entry.Unit + "\x00" + entry.Msg
+		counts[key]++
+		if counts[key] <= c.seen[key] {
+			continue
+		}
+		c.seen[key] = counts[key]
+		unseen = append(unseen, entry)
+	}
+	return unseen
+}
+
+// FollowLogs emits the most recent log entries and then polls for new entries until
+// ctx is done, at which point ctx.Err() is returned
+func (s *Services) FollowLogs(ctx context.Context, in ServiceFollowLogsIn) error {
+	limit := in.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	interval := in.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	out, err := s.Logs(ctx, ServiceLogsIn{
+		Project:   in.Project,
+		Service:   in.Service,
+		Limit:     limit,
+		SortOrder: SortOrderDesc,
+	})
+	if err != nil {
+		return err
+	}
+
+	var cursor LogCursor
+	recent := make([]ServiceLogEntry, 0, len(out.Logs))
+	for i := len(out.Logs) - 1; i >= 0; i-- {
+		recent = append(recent, out.Logs[i])
+	}
+	for _, entry := range cursor.Next(recent) {
+		in.Callback(entry)
+	}
+
+	// with descending order, offset continues towards older entries while
+	// first_log_offset marks the newest entry returned, which is where following starts
+	offset := out.FirstLogOffset
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		out, err := s.Logs(ctx, ServiceLogsIn{
+			Project:   in.Project,
+			Service:   in.Service,
+			Limit:     limit,
+			Offset:    offset,
+			SortOrder: SortOrderAsc,
+		})
+		if err != nil {
+			return err
+		}
+		for _, entry := range cursor.Next(out.Logs) {
+			in.Callback(entry)
+		}
+		if out.Offset != "" {
+			offset = out.Offset
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type ServiceMaintenanceIn struct {
+	Project string
+	Service string
+}
+
+// Maintenance returns the maintenance window of the service along with any pending updates
+func (s *Services) Maintenance(ctx context.Context, in ServiceMaintenanceIn) (ServiceMaintenance, error) {
+	service, err := s.Get(ctx, ServiceGetIn{
+		Project: in.Project,
+		Service: in.Service,
+	})
+	if err != nil {
+		return ServiceMaintenance{}, errors.Wrapf(err, "unable to retrieve maintenance for project:service, %v:%v", in.Project, in.Service)
+	}
+	if service.Maintenance == nil {
+		return ServiceMaintenance{}, nil
+	}
+
+	return *service.Maintenance, nil
+}
+
+type ServiceSetMaintenanceIn struct {
+	Project string
+	Service string
+	DOW     string // DOW is the day of week e.g. sunday
+	Time    string // Time is the utc start of the window e.g. 02:00:00
+}
+
+// maintenanceDOW holds the days of week accepted for a maintenance window
+var maintenanceDOW = map[string]bool{
+	"monday":    true,
+	"tuesday":   true,
+	"wednesday": true,
+	"thursday":  true,
+	"friday":    true,
+	"saturday":  true,
+	"sunday":    true,
+}
+
+// NewServiceMaintenance returns the maintenance window starting on the day of week dow,
+// e.g. sunday, at the utc time t, e.g. 02:00:00. Either may be empty when only part of
+// the window is being changed.
+func NewServiceMaintenance(dow, t string) (ServiceMaintenance, error) {
+	if dow != "" && !maintenanceDOW[strings.ToLower(dow)] {
+		return ServiceMaintenance{}, errors.Errorf("invalid maintenance day of week, %v; expected monday through sunday", dow)
+	}
+	if t != "" {
+		if _, err := time.Parse("15:04:05", t); err != nil {
+			return ServiceMaintenance{}, errors.Errorf("invalid maintenance time, %v; expected utc time as HH:MM:SS", t)
+		}
+	}
+
+	return ServiceMaintenance{
+		DOW:  strings.ToLower(dow),
+		Time: t,
+	}, nil
+}
+
+// SetMaintenance changes the maintenance window of the service
+func (s *Services) SetMaintenance(ctx context.Context, in ServiceSetMaintenanceIn) (ServiceMaintenance, error) {
+	if in.DOW == "" || in.Time == "" {
+		return ServiceMaintenance{}, errors.Errorf("both maintenance day of week and time are required")
+	}
+	maintenance, err := NewServiceMaintenance(in.DOW, in.Time)
+	if err != nil {
+		return ServiceMaintenance{}, err
+	}
+
+	service, err := s.Update(ctx, ServiceUpdateIn{
+		Project:     in.Project,
+		Service:     in.Service,
+		Maintenance: &maintenance,
+	})
+	if err != nil {
+		return ServiceMaintenance{}, err
+	}
+	if service.Maintenance == nil {
+		return ServiceMaintenance{}, nil
+	}
+
+	return *service.Maintenance, nil
+}
+
+type ServiceStartMaintenanceIn struct {
+	Project string
+	Service string
+}
+
+// StartMaintenance applies pending maintenance updates immediately rather than waiting
+// for the maintenance window
+func (s *Services) StartMaintenance(ctx context.Context, in ServiceStartMaintenanceIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/maintenance/start", in.Project, in.Service)
+	out := apiErrors{}
+	if err := s.client.Put(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to start maintenance for project:service, %v:%v", in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to start maintenance for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	return nil
+}
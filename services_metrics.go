@@ -0,0 +1,136 @@
+package aiven
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	MetricsPeriodHour  = "hour"
+	MetricsPeriodDay   = "day"
+	MetricsPeriodWeek  = "week"
+	MetricsPeriodMonth = "month"
+	MetricsPeriodYear  = "year"
+)
+
+// MetricPoint is a single sample of a metric
+type MetricPoint struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// MetricSeries holds the samples of a metric for a single node or dimension
+type MetricSeries struct {
+	Label  string        `json:"label"`
+	Points []MetricPoint `json:"points"`
+}
+
+// Latest returns the most recent sample in the series. ok is false if the series is empty.
+func (m MetricSeries) Latest() (point MetricPoint, ok bool) {
+	if len(m.Points) == 0 {
+		return MetricPoint{}, false
+	}
+	return m.Points[len(m.Points)-1], true
+}
+
+// ServiceMetric holds the time series of a single service metric e.g. cpu_usage
+type ServiceMetric struct {
+	Name   string         `json:"name"`
+	Title  string         `json:"title"`
+	Series []MetricSeries `json:"series"`
+}
+
+// UnmarshalJSON decodes the tabular chart format aiven returns metrics in. The first
+// column of each row holds the sample time and the remaining columns one value per series.
+func (m *ServiceMetric) UnmarshalJSON(data []byte) error {
+	raw := struct {
+		Data struct {
+			Cols []struct {
+				Label string `json:"label"`
+				Type  string `json:"type"`
+			} `json:"cols"`
+			Rows [][]interface{} `json:"rows"`
+		} `json:"data"`
+		Hints struct {
+			Title string `json:"title"`
+		} `json:"hints"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	m.Title = raw.Hints.Title
+	m.Series = nil
+	if len(raw.Data.Cols) < 2 {
+		return nil
+	}
+
+	m.Series = make([]MetricSeries, len(raw.Data.Cols)-1)
+	for i, col := range raw.Data.Cols[1:] {
+		m.Series[i].Label = col.Label
+	}
+
+	for _, row := range raw.Data.Rows {
+		if len(row) == 0 {
+			continue
+		}
+		ts, ok := row[0].(string)
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, ts)
+		if err != nil {
+			return errors.Wrapf(err, "unable to parse metric time, %v", ts)
+		}
+
+		for i, v := range row[1:] {
+			value, ok := v.(float64)
+			if !ok || i >= len(m.Series) {
+				continue // null samples are skipped
+			}
+			m.Series[i].Points = append(m.Series[i].Points, MetricPoint{Time: t, Value: value})
+		}
+	}
+
+	return nil
+}
+
+type ServiceMetricsIn struct {
+	Project string `json:"-"`
+	Service string `json:"-"`
+	Period  string `json:"period"` // Period is one of hour, day, week, month or year
+}
+
+// Metrics returns the time series of the service metrics e.g. cpu, disk and network usage
+// over the requested period, sorted by name
+func (s *Services) Metrics(ctx context.Context, in ServiceMetricsIn) ([]ServiceMetric, error) {
+	if in.Period == "" {
+		in.Period = MetricsPeriodHour
+	}
+
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/service/%v/metrics", in.Project, in.Service)
+	out := struct {
+		apiErrors
+		Metrics map[string]ServiceMetric `json:"metrics"`
+	}{}
+	if err := s.client.Post(ctx, u, in, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to retrieve metrics for project:service, %v:%v", in.Project, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to retrieve metrics for project:service, %v:%v", in.Project, in.Service)
+	}
+
+	metrics := make([]ServiceMetric, 0, len(out.Metrics))
+	for name, metric := range out.Metrics {
+		metric.Name = name
+		metrics = append(metrics, metric)
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+
+	return metrics, nil
+}
@@ -0,0 +1,693 @@
+package aiven_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServices(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+	service := os.Getenv("AIVEN_SERVICE")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+	if service == "" {
+		t.Skip("AIVEN_SERVICE not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	api := client.Services()
+	services, err := api.List(context.Background(), aiven.ServiceListIn{
+		Project: project,
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, services)
+
+	out, err := api.Get(context.Background(), aiven.ServiceGetIn{
+		Project: project,
+		Service: service,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, service, out.ServiceName)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(out)
+}
+
+func TestServiceProgressUpdatePercent(t *testing.T) {
+	testCases := map[string]struct {
+		In       aiven.ServiceProgressUpdate
+		Expected float64
+	}{
+		"completed": {
+			In:       aiven.ServiceProgressUpdate{Completed: true},
+			Expected: 100,
+		},
+		"halfway": {
+			In:       aiven.ServiceProgressUpdate{Min: 0, Max: 200, Current: 100},
+			Expected: 50,
+		},
+		"offset": {
+			In:       aiven.ServiceProgressUpdate{Min: 100, Max: 200, Current: 125},
+			Expected: 25,
+		},
+		"no range": {
+			In:       aiven.ServiceProgressUpdate{Current: 10},
+			Expected: 0,
+		},
+		"overshoot": {
+			In:       aiven.ServiceProgressUpdate{Min: 0, Max: 10, Current: 20},
+			Expected: 100,
+		},
+	}
+
+	for label, tc := range testCases {
+		t.Run(label, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, tc.In.Percent())
+		})
+	}
+}
+
+func TestWaitRunning(t *testing.T) {
+	// fakeStates answers each poll of the service with the next of states, repeating the last
+	fakeStates := func(t *testing.T, states ...aiven.Service) *int {
+		var polls int
+		fakeAPI(t, func(w http.ResponseWriter, req *http.Request) {
+			service := states[len(states)-1]
+			if polls < len(states) {
+				service = states[polls]
+			}
+			polls++
+			json.NewEncoder(w).Encode(map[string]interface{}{"service": service})
+		})
+		return &polls
+	}
+	node := func(state string) []aiven.ServiceNodeState {
+		return []aiven.ServiceNodeState{{Name: "node-1", State: "running"}, {Name: "node-2", State: state}}
+	}
+	in := aiven.ServiceWaitIn{
+		Project:  "project",
+		Service:  "service",
+		Interval: time.Millisecond,
+	}
+
+	t.Run("rebuilding to running", func(t *testing.T) {
+		polls := fakeStates(t,
+			aiven.Service{State: aiven.ServiceStateRebuilding, NodeStates: node("setting_up_vm")},
+			aiven.Service{State: aiven.ServiceStateRunning, NodeStates: node("syncing_data")},
+			aiven.Service{State: aiven.ServiceStateRunning, NodeStates: node("running")},
+		)
+
+		var progress []aiven.ServiceProgress
+		in := in
+		in.Progress = func(p aiven.ServiceProgress) {
+			progress = append(progress, p)
+		}
+
+		service, err := aiven.NewWithToken("token").Services().WaitRunning(context.Background(), in)
+		assert.Nil(t, err)
+		assert.Equal(t, aiven.ServiceStateRunning, service.State)
+		assert.Equal(t, 3, *polls)
+		assert.Len(t, progress, 3)
+		assert.Equal(t, 1, progress[1].NodesRunning)
+		assert.Equal(t, 2, progress[2].NodesRunning)
+		assert.Equal(t, 2, progress[2].Nodes)
+	})
+
+	t.Run("powered off", func(t *testing.T) {
+		polls := fakeStates(t,
+			aiven.Service{State: aiven.ServiceStateRebuilding},
+			aiven.Service{State: aiven.ServiceStatePowerOff},
+		)
+
+		service, err := aiven.NewWithToken("token").Services().WaitRunning(context.Background(), in)
+		assert.NotNil(t, err)
+		assert.Equal(t, aiven.ServiceStatePowerOff, service.State)
+		assert.Equal(t, 2, *polls)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		fakeStates(t, aiven.Service{State: aiven.ServiceStateRebuilding})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		service, err := aiven.NewWithToken("token").Services().WaitRunning(ctx, in)
+		assert.NotNil(t, err)
+		assert.Equal(t, context.DeadlineExceeded, errors.Cause(err))
+		assert.Equal(t, aiven.ServiceStateRebuilding, service.State)
+	})
+}
+
+func TestServiceLogs(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+	service := os.Getenv("AIVEN_SERVICE")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+	if service == "" {
+		t.Skip("AIVEN_SERVICE not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	out, err := client.Services().Logs(context.Background(), aiven.ServiceLogsIn{
+		Project:   project,
+		Service:   service,
+		Limit:     10,
+		SortOrder: aiven.SortOrderDesc,
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, out.Offset)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(out)
+}
+
+func TestFollowLogs(t *testing.T) {
+	at := func(minute int) time.Time {
+		return time.Date(2026, time.October, 19, 10, minute, 0, 0, time.UTC)
+	}
+	entries := []aiven.ServiceLogEntry{
+		{Msg: "one", Time: at(1)},
+		{Msg: "two", Time: at(2)},
+		{Msg: "three", Time: at(3)},
+		{Msg: "four", Time: at(4)},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var requests []aiven.ServiceLogsIn
+	fakeAPI(t, func(w http.ResponseWriter, req *http.Request) {
+		var in aiven.ServiceLogsIn
+		json.NewDecoder(req.Body).Decode(&in)
+		requests = append(requests, in)
+
+		var out aiven.ServiceLogsOut
+		switch len(requests) {
+		case 1:
+			// newest first, offset continues towards older entries
+			out = aiven.ServiceLogsOut{
+				FirstLogOffset: "3",
+				Logs:           []aiven.ServiceLogEntry{entries[2], entries[1], entries[0]},
+				Offset:         "0",
+			}
+		case 2:
+			// the entry at the offset is returned again along with the new entry
+			out = aiven.ServiceLogsOut{
+				Logs:   []aiven.ServiceLogEntry{entries[2], entries[3]},
+				Offset: "4",
+			}
+		default:
+			out = aiven.ServiceLogsOut{
+				Logs:   []aiven.ServiceLogEntry{entries[3]},
+				Offset: "4",
+			}
+			cancel()
+		}
+		json.NewEncoder(w).Encode(out)
+	})
+
+	var emitted []aiven.ServiceLogEntry
+	err := aiven.NewWithToken("token").Services().FollowLogs(ctx, aiven.ServiceFollowLogsIn{
+		Project:  "project",
+		Service:  "service",
+		Interval: time.Millisecond,
+		Callback: func(entry aiven.ServiceLogEntry) {
+			emitted = append(emitted, entry)
+		},
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, entries, emitted)
+
+	assert.Len(t, requests, 3)
+	assert.Equal(t, aiven.SortOrderDesc, requests[0].SortOrder)
+	assert.Equal(t, aiven.SortOrderAsc, requests[1].SortOrder)
+	assert.Equal(t, "3", requests[1].Offset)
+	assert.Equal(t, "4", requests[2].Offset)
+}
+
+func TestLogCursor(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+
+	var cursor aiven.LogCursor
+	got := cursor.Next([]aiven.ServiceLogEntry{
+		{Msg: "a", Time: at},
+		{Msg: "b", Time: at},
+	})
+	assert.Len(t, got, 2)
+
+	// same timestamp across polls; only the new message is emitted
+	got = cursor.Next([]aiven.ServiceLogEntry{
+		{Msg: "a", Time: at},
+		{Msg: "b", Time: at},
+		{Msg: "c", Time: at},
+	})
+	assert.Equal(t, []aiven.ServiceLogEntry{{Msg: "c", Time: at}}, got)
+
+	// older entries are never emitted again
+	got = cursor.Next([]aiven.ServiceLogEntry{
+		{Msg: "old", Time: at.Add(-time.Second)},
+		{Msg: "a", Time: at.Add(time.Second)},
+	})
+	assert.Equal(t, []aiven.ServiceLogEntry{{Msg: "a", Time: at.Add(time.Second)}}, got)
+
+	// identical lines within a timestamp are each emitted once
+	later := at.Add(2 * time.Second)
+	got = cursor.Next([]aiven.ServiceLogEntry{
+		{Msg: "retry", Time: later},
+		{Msg: "retry", Time: later},
+	})
+	assert.Len(t, got, 2)
+
+	got = cursor.Next([]aiven.ServiceLogEntry{
+		{Msg: "retry", Time: later},
+		{Msg: "retry", Time: later},
+		{Msg: "retry", Time: later},
+	})
+	assert.Equal(t, []aiven.ServiceLogEntry{{Msg: "retry", Time: later}}, got)
+}
+
+func TestMaintenance(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+	service := os.Getenv("AIVEN_SERVICE")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+	if service == "" {
+		t.Skip("AIVEN_SERVICE not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	maintenance, err := client.Services().Maintenance(context.Background(), aiven.ServiceMaintenanceIn{
+		Project: project,
+		Service: service,
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, maintenance.DOW)
+	assert.NotEmpty(t, maintenance.Time)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(maintenance)
+}
+
+func TestSetMaintenance(t *testing.T) {
+	var sent []aiven.ServiceMaintenance
+	fakeAPI(t, func(w http.ResponseWriter, req *http.Request) {
+		var in struct {
+			Maintenance aiven.ServiceMaintenance `json:"maintenance"`
+		}
+		json.NewDecoder(req.Body).Decode(&in)
+		sent = append(sent, in.Maintenance)
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"service": aiven.Service{Maintenance: &in.Maintenance},
+		})
+	})
+
+	testCases := map[string]struct {
+		DOW     string
+		Time    string
+		WantDOW string
+		Err     bool
+	}{
+		"valid": {
+			DOW:     "sunday",
+			Time:    "02:00:00",
+			WantDOW: "sunday",
+		},
+		"mixed case": {
+			DOW:     "Tuesday",
+			Time:    "23:30:00",
+			WantDOW: "tuesday",
+		},
+		"abbreviated dow": {
+			DOW:  "sun",
+			Time: "02:00:00",
+			Err:  true,
+		},
+		"empty dow": {
+			Time: "02:00:00",
+			Err:  true,
+		},
+		"missing seconds": {
+			DOW:  "sunday",
+			Time: "02:00",
+			Err:  true,
+		},
+		"out of range": {
+			DOW:  "sunday",
+			Time: "24:00:00",
+			Err:  true,
+		},
+	}
+
+	client := aiven.NewWithToken("token")
+	for label, tc := range testCases {
+		t.Run(label, func(t *testing.T) {
+			sent = nil
+			maintenance, err := client.Services().SetMaintenance(context.Background(), aiven.ServiceSetMaintenanceIn{
+				Project: "project",
+				Service: "service",
+				DOW:     tc.DOW,
+				Time:    tc.Time,
+			})
+			if tc.Err {
+				assert.NotNil(t, err)
+				assert.Len(t, sent, 0)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Len(t, sent, 1)
+			assert.Equal(t, tc.WantDOW, sent[0].DOW)
+			assert.Equal(t, tc.Time, sent[0].Time)
+			assert.Equal(t, tc.WantDOW, maintenance.DOW)
+		})
+	}
+}
+
+func TestNewServiceMaintenance(t *testing.T) {
+	testCases := map[string]struct {
+		DOW  string
+		Time string
+		Want aiven.ServiceMaintenance
+		Err  bool
+	}{
+		"both":         {DOW: "Sunday", Time: "02:00:00", Want: aiven.ServiceMaintenance{DOW: "sunday", Time: "02:00:00"}},
+		"dow only":     {DOW: "monday", Want: aiven.ServiceMaintenance{DOW: "monday"}},
+		"time only":    {Time: "23:30:00", Want: aiven.ServiceMaintenance{Time: "23:30:00"}},
+		"invalid dow":  {DOW: "someday", Err: true},
+		"invalid time": {Time: "2am", Err: true},
+	}
+
+	for label, tc := range testCases {
+		t.Run(label, func(t *testing.T) {
+			got, err := aiven.NewServiceMaintenance(tc.DOW, tc.Time)
+			if tc.Err {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.Want, got)
+		})
+	}
+}
+
+func TestServiceMetricUnmarshal(t *testing.T) {
+	data := `{
+  "data": {
+    "cols": [
+      {"label": "time", "type": "date"},
+      {"label": "node-1", "type": "number"},
+      {"label": "node-2", "type": "number"}
+    ],
+    "rows": [
+      ["2026-10-19T10:00:00Z", 12.5, 20],
+      ["2026-10-19T10:00:30Z", 13.5, null]
+    ]
+  },
+  "hints": {"title": "CPU usage %"}
+}`
+
+	var metric aiven.ServiceMetric
+	err := json.Unmarshal([]byte(data), &metric)
+	assert.Nil(t, err)
+	assert.Equal(t, "CPU usage %", metric.Title)
+	assert.Len(t, metric.Series, 2)
+	assert.Equal(t, "node-1", metric.Series[0].Label)
+	assert.Len(t, metric.Series[0].Points, 2)
+	assert.Len(t, metric.Series[1].Points, 1)
+
+	latest, ok := metric.Series[0].Latest()
+	assert.True(t, ok)
+	assert.Equal(t, 13.5, latest.Value)
+}
+
+func TestServiceBackups(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+	service := os.Getenv("AIVEN_SERVICE")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+	if service == "" {
+		t.Skip("AIVEN_SERVICE not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	out, err := client.Services().Backups(context.Background(), aiven.ServiceBackupsIn{
+		Project: project,
+		Service: service,
+	})
+	assert.Nil(t, err)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(out)
+}
+
+func TestFork(t *testing.T) {
+	target := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	testCases := map[string]struct {
+		In             aiven.ServiceForkIn
+		WantUserConfig map[string]interface{}
+	}{
+		"latest": {
+			In: aiven.ServiceForkIn{},
+			WantUserConfig: map[string]interface{}{
+				"service_to_fork_from": "source",
+			},
+		},
+		"point in time": {
+			In: aiven.ServiceForkIn{RecoveryTargetTime: &target},
+			WantUserConfig: map[string]interface{}{
+				"service_to_fork_from": "source",
+				"recovery_target_time": "2026-10-19T08:00:00Z",
+			},
+		},
+		"backup": {
+			In: aiven.ServiceForkIn{BackupName: "backup-1"},
+			WantUserConfig: map[string]interface{}{
+				"service_to_fork_from":     "source",
+				"recovery_basebackup_name": "backup-1",
+			},
+		},
+	}
+
+	for label, tc := range testCases {
+		t.Run(label, func(t *testing.T) {
+			var created map[string]interface{}
+			fakeAPI(t, func(w http.ResponseWriter, req *http.Request) {
+				if req.Method == http.MethodPost {
+					json.NewDecoder(req.Body).Decode(&created)
+				}
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"service": aiven.Service{Plan: "business-4", CloudName: "google-europe-west1", ServiceType: "pg"},
+				})
+			})
+
+			in := tc.In
+			in.Project, in.Service, in.ServiceName = "project", "source", "fork"
+			_, err := aiven.NewWithToken("token").Services().Fork(context.Background(), in)
+			assert.Nil(t, err)
+			assert.Equal(t, "fork", created["service_name"])
+			assert.Equal(t, "pg", created["service_type"])
+			assert.Equal(t, "business-4", created["plan"])
+			assert.Equal(t, "google-europe-west1", created["cloud"])
+			assert.Equal(t, tc.WantUserConfig, created["user_config"])
+		})
+	}
+
+	t.Run("target time and backup", func(t *testing.T) {
+		var calls int
+		fakeAPI(t, func(w http.ResponseWriter, req *http.Request) {
+			calls++
+		})
+
+		_, err := aiven.NewWithToken("token").Services().Fork(context.Background(), aiven.ServiceForkIn{
+			Project:            "project",
+			Service:            "source",
+			ServiceName:        "fork",
+			RecoveryTargetTime: &target,
+			BackupName:         "backup-1",
+		})
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, calls)
+	})
+}
+
+func TestServiceIPFilter(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+	service := os.Getenv("AIVEN_SERVICE")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+	if service == "" {
+		t.Skip("AIVEN_SERVICE not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	out, err := client.Services().IPFilter(context.Background(), aiven.ServiceIPFilterIn{
+		Project: project,
+		Service: service,
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, out)
+}
+
+// fakeIPFilter serves a service whose user config holds ipFilter, replacing it with
+// each update and recording the ip_filter sent by each update
+func fakeIPFilter(t *testing.T, ipFilter []interface{}) *[][]interface{} {
+	var updates [][]interface{}
+	fakeAPI(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPut {
+			var in struct {
+				UserConfig map[string][]interface{} `json:"user_config"`
+			}
+			json.NewDecoder(req.Body).Decode(&in)
+			ipFilter = in.UserConfig["ip_filter"]
+			updates = append(updates, ipFilter)
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"service": map[string]interface{}{
+				"user_config": map[string]interface{}{
+					"ip_filter": ipFilter,
+				},
+			},
+		})
+	})
+	return &updates
+}
+
+func TestIPFilterEntries(t *testing.T) {
+	fakeIPFilter(t, []interface{}{
+		"10.0.0.0/8",
+		map[string]interface{}{"network": "192.168.1.0/24", "description": "office"},
+		42,
+	})
+
+	entries, err := aiven.NewWithToken("token").Services().IPFilter(context.Background(), aiven.ServiceIPFilterIn{
+		Project: "project",
+		Service: "service",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []aiven.IPFilterEntry{
+		{Network: "10.0.0.0/8"},
+		{Network: "192.168.1.0/24", Description: "office"},
+		{},
+	}, entries)
+}
+
+func TestAddIPFilter(t *testing.T) {
+	updates := fakeIPFilter(t, []interface{}{
+		"10.0.0.0/8",
+		map[string]interface{}{"network": "192.168.1.0/24", "description": "office"},
+	})
+
+	entries, err := aiven.NewWithToken("token").Services().AddIPFilter(context.Background(), aiven.ServiceAddIPFilterIn{
+		Project: "project",
+		Service: "service",
+		Entries: []aiven.IPFilterEntry{
+			{Network: "10.0.0.0/8", Description: "already present"},
+			{Network: "172.16.0.0/12"},
+			{Network: "203.0.113.7/32", Description: "vpn"},
+			{Network: "172.16.0.0/12"},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 4)
+
+	// existing entries keep their form; new entries with a description use the object form
+	assert.Equal(t, [][]interface{}{{
+		"10.0.0.0/8",
+		map[string]interface{}{"network": "192.168.1.0/24", "description": "office"},
+		"172.16.0.0/12",
+		map[string]interface{}{"network": "203.0.113.7/32", "description": "vpn"},
+	}}, *updates)
+}
+
+func TestRemoveIPFilter(t *testing.T) {
+	services := aiven.NewWithToken("token").Services()
+
+	t.Run("keeps others", func(t *testing.T) {
+		updates := fakeIPFilter(t, []interface{}{
+			"10.0.0.0/8",
+			map[string]interface{}{"network": "192.168.1.0/24", "description": "office"},
+			"172.16.0.0/12",
+		})
+
+		entries, err := services.RemoveIPFilter(context.Background(), aiven.ServiceRemoveIPFilterIn{
+			Project:  "project",
+			Service:  "service",
+			Networks: []string{"10.0.0.0/8", "198.51.100.0/24"},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []aiven.IPFilterEntry{
+			{Network: "192.168.1.0/24", Description: "office"},
+			{Network: "172.16.0.0/12"},
+		}, entries)
+		assert.Equal(t, [][]interface{}{{
+			map[string]interface{}{"network": "192.168.1.0/24", "description": "office"},
+			"172.16.0.0/12",
+		}}, *updates)
+	})
+
+	t.Run("refuses to empty", func(t *testing.T) {
+		updates := fakeIPFilter(t, []interface{}{"10.0.0.0/8"})
+
+		_, err := services.RemoveIPFilter(context.Background(), aiven.ServiceRemoveIPFilterIn{
+			Project:  "project",
+			Service:  "service",
+			Networks: []string{"10.0.0.0/8"},
+		})
+		assert.Equal(t, aiven.ErrIPFilterEmpty, err)
+		assert.Len(t, *updates, 0)
+	})
+
+	t.Run("force empty", func(t *testing.T) {
+		updates := fakeIPFilter(t, []interface{}{"10.0.0.0/8"})
+
+		entries, err := services.RemoveIPFilter(context.Background(), aiven.ServiceRemoveIPFilterIn{
+			Project:  "project",
+			Service:  "service",
+			Networks: []string{"10.0.0.0/8"},
+			Force:    true,
+		})
+		assert.Nil(t, err)
+		assert.Len(t, entries, 0)
+		assert.Equal(t, [][]interface{}{{}}, *updates)
+	})
+}
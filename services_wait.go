@@ -0,0 +1,100 @@
+package aiven
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	NodeStateRunning = "running"
+)
+
+// Percent returns how far the update has progressed, from 0 to 100
+func (p ServiceProgressUpdate) Percent() float64 {
+	if p.Completed {
+		return 100
+	}
+	if p.Max <= p.Min {
+		return 0
+	}
+
+	v := float64(p.Current-p.Min) / float64(p.Max-p.Min) * 100
+	if v < 0 {
+		return 0
+	}
+	if v > 100 {
+		return 100
+	}
+	return v
+}
+
+// ServiceProgress reports the state of a service while waiting for it to start
+type ServiceProgress struct {
+	State        string             `json:"state"`
+	NodeStates   []ServiceNodeState `json:"node_states"`
+	NodesRunning int                `json:"nodes_running"`
+	Nodes        int                `json:"nodes"`
+	Elapsed      time.Duration      `json:"elapsed"`
+}
+
+type ServiceWaitIn struct {
+	Project string
+	Service string
+
+	// Interval between polls of the service state; defaults to 10s
+	Interval time.Duration
+
+	// Progress, if set, is invoked after each poll
+	Progress func(ServiceProgress)
+}
+
+// WaitRunning polls the service until it and all of its nodes are RUNNING. Use ctx to
+// bound how long to wait. An error is returned if the service is powered off.
+func (s *Services) WaitRunning(ctx context.Context, in ServiceWaitIn) (Service, error) {
+	interval := in.Interval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	started := time.Now()
+	for {
+		service, err := s.Get(ctx, ServiceGetIn{
+			Project: in.Project,
+			Service: in.Service,
+		})
+		if err != nil {
+			return Service{}, err
+		}
+
+		progress := ServiceProgress{
+			State:      service.State,
+			NodeStates: service.NodeStates,
+			Nodes:      len(service.NodeStates),
+			Elapsed:    time.Since(started),
+		}
+		for _, node := range service.NodeStates {
+			if strings.EqualFold(node.State, NodeStateRunning) {
+				progress.NodesRunning++
+			}
+		}
+		if in.Progress != nil {
+			in.Progress(progress)
+		}
+
+		switch {
+		case service.State == ServiceStateRunning && progress.NodesRunning == progress.Nodes:
+			return service, nil
+		case service.State == ServiceStatePowerOff:
+			return service, errors.Errorf("service, %v:%v, is powered off", in.Project, in.Service)
+		}
+
+		select {
+		case <-ctx.Done():
+			return service, errors.Wrapf(ctx.Err(), "timed out waiting for service, %v:%v, in state %v", in.Project, in.Service, service.State)
+		case <-time.After(interval):
+		}
+	}
+}
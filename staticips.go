@@ -0,0 +1,156 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	StaticIPStateAssigned  = "assigned"
+	StaticIPStateAvailable = "available"
+	StaticIPStateCreated   = "created"
+	StaticIPStateCreating  = "creating"
+	StaticIPStateDeleted   = "deleted"
+	StaticIPStateDeleting  = "deleting"
+)
+
+// StaticIPs provides an api into aiven static ip addresses. Services only use their
+// associated static ips once the static_ips user config option is enabled.
+type StaticIPs struct {
+	client *Client
+}
+
+// newStaticIPs accepts a valid aiven client and returns access to the StaticIPs api
+func newStaticIPs(client *Client) *StaticIPs {
+	return &StaticIPs{
+		client: client,
+	}
+}
+
+// StaticIP represents a static ip address reserved in a cloud
+type StaticIP struct {
+	CloudName             string `json:"cloud_name"`
+	IPAddress             string `json:"ip_address"`
+	ServiceName           string `json:"service_name"`
+	State                 string `json:"state"`
+	StaticIPAddressID     string `json:"static_ip_address_id"`
+	TerminationProtection bool   `json:"termination_protection"`
+}
+
+type StaticIPListIn struct {
+	Project string
+}
+
+// List returns the static ips of the project
+func (s *StaticIPs) List(ctx context.Context, in StaticIPListIn) ([]StaticIP, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/static-ips", in.Project)
+	out := struct {
+		apiErrors
+		StaticIPs []StaticIP `json:"static_ips"`
+	}{}
+	if err := s.client.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list static ips for project, %v", in.Project)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list static ips for project, %v", in.Project)
+	}
+
+	return out.StaticIPs, nil
+}
+
+type StaticIPCreateIn struct {
+	Project               string `json:"-"`
+	CloudName             string `json:"cloud_name"`
+	TerminationProtection bool   `json:"termination_protection,omitempty"`
+}
+
+// Create reserves a static ip in the specified cloud
+func (s *StaticIPs) Create(ctx context.Context, in StaticIPCreateIn) (StaticIP, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/static-ips", in.Project)
+	out := struct {
+		apiErrors
+		StaticIP
+	}{}
+	if err := s.client.Post(ctx, u, in, &out); err != nil {
+		return StaticIP{}, errors.Wrapf(err, "unable to create static ip in cloud, %v, for project, %v", in.CloudName, in.Project)
+	}
+	if err := out.err(); err != nil {
+		return StaticIP{}, errors.Wrapf(err, "unable to create static ip in cloud, %v, for project, %v", in.CloudName, in.Project)
+	}
+
+	return out.StaticIP, nil
+}
+
+type StaticIPAssociateIn struct {
+	Project    string
+	StaticIPID string
+	Service    string
+}
+
+// Associate assigns the static ip to a service
+func (s *StaticIPs) Associate(ctx context.Context, in StaticIPAssociateIn) (StaticIP, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/static-ips/%v/association", in.Project, in.StaticIPID)
+	body := struct {
+		ServiceName string `json:"service_name"`
+	}{
+		ServiceName: in.Service,
+	}
+	out := struct {
+		apiErrors
+		StaticIP
+	}{}
+	if err := s.client.Post(ctx, u, body, &out); err != nil {
+		return StaticIP{}, errors.Wrapf(err, "unable to associate static ip, %v, with service, %v", in.StaticIPID, in.Service)
+	}
+	if err := out.err(); err != nil {
+		return StaticIP{}, errors.Wrapf(err, "unable to associate static ip, %v, with service, %v", in.StaticIPID, in.Service)
+	}
+
+	return out.StaticIP, nil
+}
+
+type StaticIPDissociateIn struct {
+	Project    string
+	StaticIPID string
+}
+
+// Dissociate releases the static ip from its service
+func (s *StaticIPs) Dissociate(ctx context.Context, in StaticIPDissociateIn) (StaticIP, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/static-ips/%v/association", in.Project, in.StaticIPID)
+	out := struct {
+		apiErrors
+		StaticIP
+	}{}
+	if err := s.client.Delete(ctx, u, nil, &out); err != nil {
+		return StaticIP{}, errors.Wrapf(err, "unable to dissociate static ip, %v", in.StaticIPID)
+	}
+	if err := out.err(); err != nil {
+		return StaticIP{}, errors.Wrapf(err, "unable to dissociate static ip, %v", in.StaticIPID)
+	}
+
+	return out.StaticIP, nil
+}
+
+type StaticIPDeleteIn struct {
+	Project    string
+	StaticIPID string
+}
+
+// Delete releases the static ip. Deleting a static ip that does not exist is not an error.
+func (s *StaticIPs) Delete(ctx context.Context, in StaticIPDeleteIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/static-ips/%v", in.Project, in.StaticIPID)
+	out := apiErrors{}
+	if err := s.client.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to delete static ip, %v, for project, %v", in.StaticIPID, in.Project)
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to delete static ip, %v, for project, %v", in.StaticIPID, in.Project)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package aiven
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Tokens provides an api into the access tokens of the authenticated user
+type Tokens struct {
+	client *Client
+}
+
+// newTokens accepts a valid aiven client and returns access to the Tokens api
+func newTokens(client *Client) *Tokens {
+	return &Tokens{
+		client: client,
+	}
+}
+
+// Token describes an access token. FullToken is only ever returned by Create.
+type Token struct {
+	CreateTime      time.Time  `json:"create_time"`
+	CreatedManually bool       `json:"created_manually"`
+	CurrentlyActive bool       `json:"currently_active"`
+	Description     string     `json:"description,omitempty"`
+	ExpiryTime      *time.Time `json:"expiry_time,omitempty"`
+	ExtendWhenUsed  bool       `json:"extend_when_used"`
+	FullToken       string     `json:"full_token,omitempty"`
+	LastIP          string     `json:"last_ip,omitempty"`
+	LastUsedTime    *time.Time `json:"last_used_time,omitempty"`
+	LastUserAgent   string     `json:"last_user_agent,omitempty"`
+	MaxAgeSeconds   int64      `json:"max_age_seconds,omitempty"`
+	TokenPrefix     string     `json:"token_prefix"`
+}
+
+// List returns the access tokens of the authenticated user
+func (t *Tokens) List(ctx context.Context) ([]Token, error) {
+	out := struct {
+		apiErrors
+		Tokens []Token `json:"tokens"`
+	}{}
+	if err := t.client.Get(ctx, "https://console.aiven.io/v1beta/access_token", &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list access tokens")
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list access tokens")
+	}
+
+	return out.Tokens, nil
+}
+
+type TokenCreateIn struct {
+	Description    string
+	MaxAge         time.Duration
+	ExtendWhenUsed bool
+}
+
+// Create mints a new access token. The token never expires if MaxAge is zero; when
+// ExtendWhenUsed is set, the expiry is pushed back by MaxAge each time the token is used.
+func (t *Tokens) Create(ctx context.Context, in TokenCreateIn) (Token, error) {
+	body := struct {
+		Description    string `json:"description,omitempty"`
+		ExtendWhenUsed bool   `json:"extend_when_used,omitempty"`
+		MaxAgeSeconds  int64  `json:"max_age_seconds,omitempty"`
+	}{
+		Description:    in.Description,
+		ExtendWhenUsed: in.ExtendWhenUsed,
+		MaxAgeSeconds:  int64(in.MaxAge / time.Second),
+	}
+	out := struct {
+		apiErrors
+		Token
+	}{}
+	if err := t.client.Post(ctx, "https://console.aiven.io/v1beta/access_token", body, &out); err != nil {
+		return Token{}, errors.Wrapf(err, "unable to create access token")
+	}
+	if err := out.err(); err != nil {
+		return Token{}, errors.Wrapf(err, "unable to create access token")
+	}
+
+	return out.Token, nil
+}
+
+type TokenRevokeIn struct {
+	// TokenPrefix identifies the token; the full token is also accepted
+	TokenPrefix string
+}
+
+// Revoke invalidates the token. Revoking a token that does not exist is not an error.
+// TokenPrefix is required as an empty prefix would address the token of the request.
+func (t *Tokens) Revoke(ctx context.Context, in TokenRevokeIn) error {
+	if in.TokenPrefix == "" {
+		return errors.New("unable to revoke access token: token prefix is required")
+	}
+
+	u := "https://console.aiven.io/v1beta/access_token/" + url.PathEscape(in.TokenPrefix)
+	out := apiErrors{}
+	if err := t.client.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to revoke access token")
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to revoke access token")
+	}
+
+	return nil
+}
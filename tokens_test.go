@@ -0,0 +1,59 @@
+package aiven_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokens(t *testing.T) {
+	if os.Getenv("AIVEN_EMAIL") == "" && os.Getenv("AIVEN_TOKEN") == "" {
+		t.Skip("AIVEN_EMAIL not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	ctx := context.Background()
+	api := client.Tokens()
+
+	token, err := api.Create(ctx, aiven.TokenCreateIn{
+		Description: "aiven go client test",
+		MaxAge:      time.Hour,
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, token.FullToken)
+
+	tokenClient := aiven.NewWithToken(token.FullToken)
+	tokens, err := tokenClient.Tokens().List(ctx)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, tokens)
+
+	err = api.Revoke(ctx, aiven.TokenRevokeIn{
+		TokenPrefix: token.TokenPrefix,
+	})
+	assert.Nil(t, err)
+}
+
+func TestRevokeToken(t *testing.T) {
+	var paths []string
+	fakeAPI(t, func(w http.ResponseWriter, req *http.Request) {
+		paths = append(paths, req.URL.Path)
+		w.Write([]byte(`{}`))
+	})
+
+	client := aiven.NewWithToken("token")
+	err := client.Tokens().Revoke(context.Background(), aiven.TokenRevokeIn{})
+	assert.NotNil(t, err)
+	assert.Len(t, paths, 0, "an empty prefix is never sent")
+
+	err = client.Tokens().Revoke(context.Background(), aiven.TokenRevokeIn{TokenPrefix: "abc"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/v1beta/access_token/abc"}, paths)
+}
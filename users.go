@@ -0,0 +1,49 @@
+package aiven
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// User describes the authenticated user
+type User struct {
+	AuthenticationMethods []map[string]interface{} `json:"authentication_methods,omitempty"`
+	CreateTime            time.Time                `json:"create_time"`
+	Projects              []string                 `json:"projects,omitempty"`
+	RealName              string                   `json:"real_name"`
+	State                 string                   `json:"state"`
+	UserEmail             string                   `json:"user"`
+	UserID                string                   `json:"user_id"`
+}
+
+// Me returns the authenticated user; useful for checking a token is still valid
+func (c *Client) Me(ctx context.Context) (User, error) {
+	out := struct {
+		apiErrors
+		User User `json:"user"`
+	}{}
+	if err := c.Get(ctx, "https://console.aiven.io/v1beta/me", &out); err != nil {
+		return User{}, errors.Wrapf(err, "unable to retrieve authenticated user")
+	}
+	if err := out.err(); err != nil {
+		return User{}, errors.Wrapf(err, "unable to retrieve authenticated user")
+	}
+
+	return out.User, nil
+}
+
+// Logout invalidates the token the client authenticates with
+func (c *Client) Logout(ctx context.Context) error {
+	out := apiErrors{}
+	if err := c.Post(ctx, "https://console.aiven.io/v1beta/me/logout", nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to logout")
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to logout")
+	}
+
+	c.token = ""
+	return nil
+}
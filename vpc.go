@@ -0,0 +1,291 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	PeeringStateActive               = "ACTIVE"
+	PeeringStateApproved             = "APPROVED"
+	PeeringStateDeleted              = "DELETED"
+	PeeringStateDeletedByPeer        = "DELETED_BY_PEER"
+	PeeringStateDeleting             = "DELETING"
+	PeeringStateError                = "ERROR"
+	PeeringStateInvalidSpecification = "INVALID_SPECIFICATION"
+	PeeringStatePendingPeer          = "PENDING_PEER"
+	PeeringStateRejectedByPeer       = "REJECTED_BY_PEER"
+)
+
+// VPC provides an api into aiven project vpcs and their peering connections
+type VPC struct {
+	client *Client
+}
+
+// newVPC accepts a valid aiven client and returns access to the VPC api
+func newVPC(client *Client) *VPC {
+	return &VPC{
+		client: client,
+	}
+}
+
+// PeeringWarning describes a non-fatal problem with a peering connection e.g. overlapping cidrs
+type PeeringWarning struct {
+	ConflictingAWSAccountID string `json:"conflicting_aws_account_id,omitempty"`
+	ConflictingAWSVPCID     string `json:"conflicting_aws_vpc_peering_connection_id,omitempty"`
+	Message                 string `json:"message"`
+	Type                    string `json:"type"`
+}
+
+// PeeringStateInfo explains the state of a peering connection. While PENDING_PEER it
+// holds the details needed to accept the connection on the peer side.
+type PeeringStateInfo struct {
+	AWSVPCPeeringConnectionID string           `json:"aws_vpc_peering_connection_id,omitempty"`
+	Message                   string           `json:"message"`
+	ToTenantID                string           `json:"to-tenant-id,omitempty"`
+	Type                      string           `json:"type"`
+	Warnings                  []PeeringWarning `json:"warnings,omitempty"`
+}
+
+// PeeringConnection represents a peering between a project vpc and a vpc in a customer cloud account
+type PeeringConnection struct {
+	CreateTime           time.Time         `json:"create_time"`
+	PeerAzureAppID       string            `json:"peer_azure_app_id,omitempty"`
+	PeerAzureTenantID    string            `json:"peer_azure_tenant_id,omitempty"`
+	PeerCloudAccount     string            `json:"peer_cloud_account"`
+	PeerRegion           string            `json:"peer_region,omitempty"`
+	PeerResourceGroup    string            `json:"peer_resource_group,omitempty"`
+	PeerVPC              string            `json:"peer_vpc"`
+	State                string            `json:"state"`
+	StateInfo            *PeeringStateInfo `json:"state_info,omitempty"`
+	UpdateTime           time.Time         `json:"update_time"`
+	UserPeerNetworkCIDRs []string          `json:"user_peer_network_cidrs,omitempty"`
+}
+
+// ProjectVPC represents a vpc owned by an aiven project
+type ProjectVPC struct {
+	CloudName          string              `json:"cloud_name"`
+	CreateTime         time.Time           `json:"create_time"`
+	NetworkCIDR        string              `json:"network_cidr"`
+	PeeringConnections []PeeringConnection `json:"peering_connections,omitempty"`
+	ProjectVPCID       string              `json:"project_vpc_id"`
+	State              string              `json:"state"`
+	UpdateTime         time.Time           `json:"update_time"`
+}
+
+type VPCListIn struct {
+	Project string
+}
+
+// List returns the vpcs of the project
+func (v *VPC) List(ctx context.Context, in VPCListIn) ([]ProjectVPC, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/vpcs", in.Project)
+	out := struct {
+		apiErrors
+		VPCs []ProjectVPC `json:"vpcs"`
+	}{}
+	if err := v.client.Get(ctx, u, &out); err != nil {
+		return nil, errors.Wrapf(err, "unable to list vpcs for project, %v", in.Project)
+	}
+	if err := out.err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list vpcs for project, %v", in.Project)
+	}
+
+	return out.VPCs, nil
+}
+
+type VPCGetIn struct {
+	Project string
+	VPCID   string
+}
+
+// Get returns the vpc including its peering connections
+func (v *VPC) Get(ctx context.Context, in VPCGetIn) (ProjectVPC, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/vpcs/%v", in.Project, in.VPCID)
+	out := struct {
+		apiErrors
+		ProjectVPC
+	}{}
+	if err := v.client.Get(ctx, u, &out); err != nil {
+		return ProjectVPC{}, errors.Wrapf(err, "unable to retrieve vpc, %v, for project, %v", in.VPCID, in.Project)
+	}
+	if err := out.err(); err != nil {
+		return ProjectVPC{}, errors.Wrapf(err, "unable to retrieve vpc, %v, for project, %v", in.VPCID, in.Project)
+	}
+
+	return out.ProjectVPC, nil
+}
+
+type VPCCreateIn struct {
+	Project     string `json:"-"`
+	CloudName   string `json:"cloud_name"`
+	NetworkCIDR string `json:"network_cidr"`
+}
+
+// Create creates a vpc in the specified cloud
+func (v *VPC) Create(ctx context.Context, in VPCCreateIn) (ProjectVPC, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/vpcs", in.Project)
+	body := struct {
+		VPCCreateIn
+		PeeringConnections []PeeringConnection `json:"peering_connections"`
+	}{
+		VPCCreateIn:        in,
+		PeeringConnections: []PeeringConnection{},
+	}
+	out := struct {
+		apiErrors
+		ProjectVPC
+	}{}
+	if err := v.client.Post(ctx, u, body, &out); err != nil {
+		return ProjectVPC{}, errors.Wrapf(err, "unable to create vpc in cloud, %v, for project, %v", in.CloudName, in.Project)
+	}
+	if err := out.err(); err != nil {
+		return ProjectVPC{}, errors.Wrapf(err, "unable to create vpc in cloud, %v, for project, %v", in.CloudName, in.Project)
+	}
+
+	return out.ProjectVPC, nil
+}
+
+type VPCDeleteIn struct {
+	Project string
+	VPCID   string
+}
+
+// Delete removes the vpc. Deleting a vpc that does not exist is not an error.
+func (v *VPC) Delete(ctx context.Context, in VPCDeleteIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/vpcs/%v", in.Project, in.VPCID)
+	out := apiErrors{}
+	if err := v.client.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to delete vpc, %v, for project, %v", in.VPCID, in.Project)
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to delete vpc, %v, for project, %v", in.VPCID, in.Project)
+	}
+
+	return nil
+}
+
+type VPCCreatePeeringIn struct {
+	Project              string   `json:"-"`
+	VPCID                string   `json:"-"`
+	PeerCloudAccount     string   `json:"peer_cloud_account"`
+	PeerRegion           string   `json:"peer_region,omitempty"`
+	PeerVPC              string   `json:"peer_vpc"`
+	UserPeerNetworkCIDRs []string `json:"user_peer_network_cidrs,omitempty"`
+}
+
+// CreatePeering requests a peering connection to a vpc in a customer cloud account. The
+// connection must then be accepted on the peer side; see WaitPeeringActive.
+func (v *VPC) CreatePeering(ctx context.Context, in VPCCreatePeeringIn) (PeeringConnection, error) {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/vpcs/%v/peering-connections", in.Project, in.VPCID)
+	out := struct {
+		apiErrors
+		PeeringConnection
+	}{}
+	if err := v.client.Post(ctx, u, in, &out); err != nil {
+		return PeeringConnection{}, errors.Wrapf(err, "unable to create peering to %v/%v for vpc, %v", in.PeerCloudAccount, in.PeerVPC, in.VPCID)
+	}
+	if err := out.err(); err != nil {
+		return PeeringConnection{}, errors.Wrapf(err, "unable to create peering to %v/%v for vpc, %v", in.PeerCloudAccount, in.PeerVPC, in.VPCID)
+	}
+
+	return out.PeeringConnection, nil
+}
+
+type VPCDeletePeeringIn struct {
+	Project          string
+	VPCID            string
+	PeerCloudAccount string
+	PeerVPC          string
+	PeerRegion       string
+}
+
+// DeletePeering removes a peering connection. Deleting a peering that does not exist is not an error.
+func (v *VPC) DeletePeering(ctx context.Context, in VPCDeletePeeringIn) error {
+	u := fmt.Sprintf("https://console.aiven.io/v1beta/project/%v/vpcs/%v/peering-connections/peer-accounts/%v/peer-vpcs/%v", in.Project, in.VPCID, in.PeerCloudAccount, in.PeerVPC)
+	if in.PeerRegion != "" {
+		u += "/peer-regions/" + in.PeerRegion
+	}
+	out := apiErrors{}
+	if err := v.client.Delete(ctx, u, nil, &out); err != nil {
+		return errors.Wrapf(err, "unable to delete peering to %v/%v for vpc, %v", in.PeerCloudAccount, in.PeerVPC, in.VPCID)
+	}
+	if out.notFound() {
+		return nil
+	}
+	if err := out.err(); err != nil {
+		return errors.Wrapf(err, "unable to delete peering to %v/%v for vpc, %v", in.PeerCloudAccount, in.PeerVPC, in.VPCID)
+	}
+
+	return nil
+}
+
+type VPCWaitPeeringIn struct {
+	Project          string
+	VPCID            string
+	PeerCloudAccount string
+	PeerVPC          string
+	PeerRegion       string
+
+	// Interval between polls of the peering state; defaults to 10s
+	Interval time.Duration
+
+	// Progress, if set, is invoked after each poll
+	Progress func(PeeringConnection)
+}
+
+// WaitPeeringActive polls the peering connection until it becomes ACTIVE. Use ctx to bound
+// how long to wait. An error is returned if the peering is rejected, deleted or invalid.
+func (v *VPC) WaitPeeringActive(ctx context.Context, in VPCWaitPeeringIn) (PeeringConnection, error) {
+	interval := in.Interval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	for {
+		vpc, err := v.Get(ctx, VPCGetIn{
+			Project: in.Project,
+			VPCID:   in.VPCID,
+		})
+		if err != nil {
+			return PeeringConnection{}, err
+		}
+
+		var peering *PeeringConnection
+		for i, p := range vpc.PeeringConnections {
+			if p.PeerCloudAccount == in.PeerCloudAccount && p.PeerVPC == in.PeerVPC && (in.PeerRegion == "" || p.PeerRegion == in.PeerRegion) {
+				peering = &vpc.PeeringConnections[i]
+				break
+			}
+		}
+		if peering == nil {
+			return PeeringConnection{}, errors.Errorf("no peering to %v/%v found for vpc, %v", in.PeerCloudAccount, in.PeerVPC, in.VPCID)
+		}
+		if in.Progress != nil {
+			in.Progress(*peering)
+		}
+
+		switch peering.State {
+		case PeeringStateActive:
+			return *peering, nil
+		case PeeringStateDeleted, PeeringStateDeletedByPeer, PeeringStateError, PeeringStateInvalidSpecification, PeeringStateRejectedByPeer:
+			message := ""
+			if peering.StateInfo != nil {
+				message = peering.StateInfo.Message
+			}
+			return *peering, errors.Errorf("peering to %v/%v is %v: %v", in.PeerCloudAccount, in.PeerVPC, peering.State, message)
+		}
+
+		select {
+		case <-ctx.Done():
+			return *peering, errors.Wrapf(ctx.Err(), "timed out waiting for peering to %v/%v in state %v", in.PeerCloudAccount, in.PeerVPC, peering.State)
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package aiven_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/savaki/aiven"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVPC(t *testing.T) {
+	project := os.Getenv("AIVEN_PROJECT")
+
+	if project == "" {
+		t.Skip("AIVEN_PROJECT not set")
+	}
+
+	client, err := aiven.EnvAuth()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+
+	out, err := client.VPC().List(context.Background(), aiven.VPCListIn{
+		Project: project,
+	})
+	assert.Nil(t, err)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(out)
+}
+
+func TestWaitPeeringActive(t *testing.T) {
+	// fakeStates answers each poll of the vpc with the next of states for the peering, repeating the last
+	fakeStates := func(t *testing.T, states ...aiven.PeeringConnection) *int {
+		var polls int
+		fakeAPI(t, func(w http.ResponseWriter, req *http.Request) {
+			peering := states[len(states)-1]
+			if polls < len(states) {
+				peering = states[polls]
+			}
+			polls++
+			peering.PeerCloudAccount = "123456789012"
+			peering.PeerVPC = "vpc-peer"
+			json.NewEncoder(w).Encode(aiven.ProjectVPC{
+				ProjectVPCID: "vpc-id",
+				PeeringConnections: []aiven.PeeringConnection{
+					{PeerCloudAccount: "123456789012", PeerVPC: "vpc-other", State: aiven.PeeringStateRejectedByPeer},
+					peering,
+				},
+			})
+		})
+		return &polls
+	}
+	in := aiven.VPCWaitPeeringIn{
+		Project:          "project",
+		VPCID:            "vpc-id",
+		PeerCloudAccount: "123456789012",
+		PeerVPC:          "vpc-peer",
+		Interval:         time.Millisecond,
+	}
+
+	t.Run("active", func(t *testing.T) {
+		polls := fakeStates(t,
+			aiven.PeeringConnection{State: aiven.PeeringStateApproved},
+			aiven.PeeringConnection{State: aiven.PeeringStatePendingPeer},
+			aiven.PeeringConnection{State: aiven.PeeringStateActive},
+		)
+
+		var progress []string
+		in := in
+		in.Progress = func(p aiven.PeeringConnection) {
+			progress = append(progress, p.State)
+		}
+
+		peering, err := aiven.NewWithToken("token").VPC().WaitPeeringActive(context.Background(), in)
+		assert.Nil(t, err)
+		assert.Equal(t, aiven.PeeringStateActive, peering.State)
+		assert.Equal(t, "vpc-peer", peering.PeerVPC)
+		assert.Equal(t, 3, *polls)
+		assert.Equal(t, []string{aiven.PeeringStateApproved, aiven.PeeringStatePendingPeer, aiven.PeeringStateActive}, progress)
+	})
+
+	failed := map[string]string{
+		aiven.PeeringStateRejectedByPeer:       "peering rejected",
+		aiven.PeeringStateDeletedByPeer:        "peering deleted",
+		aiven.PeeringStateInvalidSpecification: "cidr overlaps",
+	}
+	for state, message := range failed {
+		t.Run(state, func(t *testing.T) {
+			polls := fakeStates(t,
+				aiven.PeeringConnection{State: aiven.PeeringStateApproved},
+				aiven.PeeringConnection{State: state, StateInfo: &aiven.PeeringStateInfo{Message: message}},
+			)
+
+			peering, err := aiven.NewWithToken("token").VPC().WaitPeeringActive(context.Background(), in)
+			assert.NotNil(t, err)
+			assert.True(t, strings.Contains(err.Error(), message))
+			assert.Equal(t, state, peering.State)
+			assert.Equal(t, 2, *polls)
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		fakeStates(t, aiven.PeeringConnection{State: aiven.PeeringStateActive})
+
+		in := in
+		in.PeerVPC = "vpc-missing"
+
+		_, err := aiven.NewWithToken("token").VPC().WaitPeeringActive(context.Background(), in)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		fakeStates(t, aiven.PeeringConnection{State: aiven.PeeringStatePendingPeer})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		peering, err := aiven.NewWithToken("token").VPC().WaitPeeringActive(ctx, in)
+		assert.NotNil(t, err)
+		assert.Equal(t, context.DeadlineExceeded, errors.Cause(err))
+		assert.Equal(t, aiven.PeeringStatePendingPeer, peering.State)
+	})
+}